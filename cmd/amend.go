@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"git-randomizer/internal/git"
+	"git-randomizer/internal/notes"
+
+	"github.com/spf13/cobra"
+)
+
+/* ---------------------- FLAGS ---------------------- */
+
+var amForce bool
+
+var amendCmd = &cobra.Command{
+	Use:   "amend",
+	Short: "Restyle the message of the last commit (tree untouched)",
+	RunE:  runAmend,
+}
+
+func init() {
+	// persona selection shares its state with `gitr commit`
+	addPersonaFlags(amendCmd, withLength, withYes)
+	amendCmd.Flags().BoolVarP(&flagNoVerify, "no-verify", "n", false, "git: bypass commit-msg hooks")
+	amendCmd.Flags().BoolVar(&flagTrailers, "trailers", false, "append Gitr-Persona/Gitr-Mood/Original-Message trailers")
+	amendCmd.Flags().BoolVarP(&amForce, "force", "f", false, "amend even if HEAD was already pushed")
+}
+
+/* ------------------- COMMAND ENTRY ------------------ */
+
+func runAmend(cmd *cobra.Command, _ []string) error {
+	if _, err := git.TopLevel(); err != nil {
+		return err
+	}
+	orig, err := git.Message("HEAD")
+	if err != nil {
+		return errors.New("❌ no commit to amend yet")
+	}
+	// restyle from the sober original if gitr already styled this commit
+	if rec, ok := notes.Read("HEAD"); ok && rec.Original != "" {
+		orig = rec.Original
+	}
+
+	remotes, err := git.RemoteBranchesContaining("HEAD")
+	if err != nil {
+		return err
+	}
+	if len(remotes) > 0 {
+		fmt.Printf("⚠️  HEAD is already pushed to: %s\n", strings.Join(remotes, ", "))
+		if !amForce {
+			return errors.New("❌ refusing to rewrite a published commit (use --force if you really mean it)")
+		}
+		fmt.Println("⚠️  --force given: you'll need to force-push afterwards.")
+	}
+
+	apiKey, err := getAPIKey(flagPass)
+	if err != nil {
+		return err
+	}
+
+	rand.Seed(time.Now().UnixNano())
+	final, err := confirmFlow(orig, pickLength(), apiKey)
+	if err != nil {
+		return err
+	}
+	if final.Message == "" {
+		fmt.Println("🚫 Aborted.")
+		return nil
+	}
+	if trailersEnabled(cmd) {
+		final.Message = withTrailers(final.Message, orig, final.Persona, final.Mood)
+	}
+
+	// --only with no pathspec: reword HEAD, ignore whatever is staged
+	args := []string{"--amend", "--only"}
+	if flagNoVerify {
+		args = append(args, "--no-verify")
+	}
+	if err := gitCommit(final.Message, args...); err != nil {
+		return err
+	}
+	fmt.Println("🎉 Commit message restyled!")
+	recordOriginal("HEAD", orig, final)
+	return nil
+}
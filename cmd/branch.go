@@ -4,12 +4,16 @@ import (
 	"bufio"
 	"errors"
 	"fmt"
+	"io"
 	"os"
-	"os/exec"
+	"path"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"git-randomizer/internal/gemini"
+	"git-randomizer/internal/git"
 	"git-randomizer/internal/styles"
 
 	"github.com/manifoldco/promptui"
@@ -28,10 +32,22 @@ var (
 	brPass       string
 	brListGroups bool
 	brSave       bool
+	brType       string
+	brTicket     string
+	brFrom       string
+	brNoCheckout bool
+	brSwitch     bool
+	brPush       bool
+	brUpstream   string
+	brPrint      bool
+	brRename     bool
+	brFromIssue  string
+	brFromChange bool
+	brYes        bool
 )
 
 var branchCmd = &cobra.Command{
-	Use:   "branch",
+	Use:   "branch [description...] | branch --rename [branch]",
 	Short: "Generate a punny branch name in character and optionally check it out",
 	RunE:  runBranch,
 }
@@ -45,11 +61,23 @@ func init() {
 	branchCmd.Flags().StringVarP(&brPass, "pass-secret", "p", "", "pass secret for GEMINI_API_KEY")
 	branchCmd.Flags().BoolVarP(&brListGroups, "list-groups", "G", false, "list persona groups & exit")
 	branchCmd.Flags().BoolVarP(&brSave, "save", "S", false, "save persona/group defaults")
+	branchCmd.Flags().StringVarP(&brType, "type", "t", "", "value for {type} in branch_template")
+	branchCmd.Flags().StringVarP(&brTicket, "ticket", "k", "", "value for {ticket} in branch_template")
+	branchCmd.Flags().StringVar(&brFrom, "from", "", "start the branch at this ref instead of HEAD")
+	branchCmd.Flags().BoolVar(&brNoCheckout, "no-checkout", false, "only create the branch, stay where you are")
+	branchCmd.Flags().BoolVar(&brSwitch, "switch", false, "use git switch -c instead of git checkout -b")
+	branchCmd.Flags().BoolVar(&brPush, "push", false, "push the new branch and set its upstream")
+	branchCmd.Flags().StringVar(&brUpstream, "set-upstream", "", "push to this remote and track it (implies --push)")
+	branchCmd.Flags().BoolVar(&brPrint, "print", false, "print the name only, create nothing (for scripts)")
+	branchCmd.Flags().StringVar(&brFromIssue, "from-issue", "", "take the description from an issue file ('-' for stdin)")
+	branchCmd.Flags().BoolVar(&brFromChange, "from-changes", false, "describe the staged and unstaged changes instead of asking")
+	branchCmd.Flags().BoolVar(&brRename, "rename", false, "give an existing branch (default: current) a persona name")
+	branchCmd.Flags().BoolVarP(&brYes, "yes", "y", false, "take the first name without prompting (implied by --from-issue -)")
 }
 
 /* ---------------------------- COMMAND ----------------------------- */
 
-func runBranch(cmd *cobra.Command, _ []string) error {
+func runBranch(cmd *cobra.Command, args []string) error {
 	if brListGroups {
 		fmt.Println("Available groups:")
 		for _, g := range styles.GroupNames() {
@@ -58,8 +86,18 @@ func runBranch(cmd *cobra.Command, _ []string) error {
 		return nil
 	}
 
-	if _, err := os.Stat(".git"); err != nil {
-		return errors.New("❌ not inside a git repository")
+	if _, err := git.TopLevel(); err != nil {
+		return err
+	}
+
+	if brFromIssue == "-" {
+		brYes = true // stdin carries the issue; nothing is left to answer prompts
+	}
+
+	if brFrom != "" {
+		if _, err := git.Output("rev-parse", "--verify", "-q", brFrom+"^{commit}"); err != nil {
+			return fmt.Errorf("❌ unknown start point %q", brFrom)
+		}
 	}
 
 	apiKey, err := getAPIKey(brPass)
@@ -67,10 +105,25 @@ func runBranch(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
-	base, err := promptBaseName()
+	if brRename {
+		return runBranchRename(apiKey, args)
+	}
+
+	base, err := branchBaseText(apiKey, args)
 	if err != nil {
 		return err
 	}
+	if brPrint {
+		return printBranchName(apiKey, base)
+	}
+
+	parts, err := gatherBranchParts(base)
+	if err == promptui.ErrInterrupt || err == promptui.ErrEOF {
+		fmt.Println("\n🚫 Aborted.")
+		return nil
+	} else if err != nil {
+		return err
+	}
 
 	persona := pickPersona()
 	mood := pickBranchMoodOnce()
@@ -79,12 +132,29 @@ func runBranch(cmd *cobra.Command, _ []string) error {
 		lengthRule = "medium"
 	}
 
+	invalid := 0
 	for {
 		slug, err := generateSlug(apiKey, base, persona, mood, lengthRule)
 		if err != nil {
 			return err
 		}
-		fmt.Printf("\n🌿 Suggested branch (%s, %s): %s\n\n", persona, mood, slug)
+		parts.Slug = slug
+		name, err := renderBranchName(parts)
+		if err != nil {
+			// never hand git a name it would reject; roll again instead
+			if invalid++; invalid >= maxAttempts {
+				return err
+			}
+			fmt.Printf("⚠️  %v, regenerating…\n", err)
+			continue
+		}
+		fmt.Printf("\n🌿 Suggested branch (%s, %s): %s\n\n", persona, mood, name)
+		if brYes {
+			if done, err := finishBranch(name, branchMeta{base, persona, mood}); done || err != nil {
+				return err
+			}
+			continue
+		}
 
 		/* -------- Confirmation prompt -------- */
 		confirm := promptui.Prompt{
@@ -101,11 +171,10 @@ func runBranch(cmd *cobra.Command, _ []string) error {
 			// user typed "n" → treat as No, fall through to menu
 		case nil:
 			if ans == "" || strings.ToLower(ans) == "y" {
-				if err := checkoutBranch(slug); err != nil {
+				if done, err := finishBranch(name, branchMeta{base, persona, mood}); done || err != nil {
 					return err
 				}
-				fmt.Println("✅ Switched to new branch!")
-				return nil
+				continue
 			}
 		default:
 			return cerr
@@ -135,12 +204,15 @@ func runBranch(cmd *cobra.Command, _ []string) error {
 			}
 			continue
 		case "Use my original text":
-			slug = slugify(base)
-			if err := checkoutBranch(slug); err != nil {
+			parts.Slug = slugify(base)
+			name, err := renderBranchName(parts)
+			if err != nil {
 				return err
 			}
-			fmt.Println("✅ Switched to new branch!")
-			return nil
+			if done, err := finishBranch(name, branchMeta{Description: base}); done || err != nil {
+				return err
+			}
+			continue
 		default: /* Cancel */
 			fmt.Println("🚫 Aborted.")
 			return nil
@@ -212,8 +284,140 @@ func pickBranchMoodOnce() string {
 
 /* ----------------------- I/O & GIT ------------------------- */
 
+// branchBaseText decides what the branch is about: an issue file, the
+// current changes, the command-line words, or else an interactive prompt.
+func branchBaseText(apiKey string, args []string) (string, error) {
+	switch {
+	case brFromIssue != "":
+		return issueText(brFromIssue)
+	case brFromChange:
+		return changesText(apiKey)
+	}
+	if base := strings.TrimSpace(strings.Join(args, " ")); base != "" {
+		return base, nil
+	}
+	return promptBaseName()
+}
+
+// issueText reads a Markdown issue and returns its title plus the start of
+// its body. A YAML front-matter title wins over the first heading.
+func issueText(file string) (string, error) {
+	var raw string
+	if file == "-" {
+		in, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", err
+		}
+		raw = string(in)
+	} else {
+		in, err := os.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+		raw = string(in)
+	}
+	text := strings.TrimSpace(strings.ReplaceAll(raw, "\r\n", "\n"))
+
+	var title string
+	if strings.HasPrefix(text, "---\n") {
+		if front, rest, ok := strings.Cut(text[4:], "\n---"); ok {
+			for _, l := range strings.Split(front, "\n") {
+				if k, v, ok := strings.Cut(l, ":"); ok && strings.TrimSpace(k) == "title" {
+					title = strings.Trim(strings.TrimSpace(v), `"'`)
+				}
+			}
+			text = strings.TrimSpace(rest)
+		}
+	}
+	if title == "" {
+		first, rest, _ := strings.Cut(text, "\n")
+		title = strings.TrimSpace(strings.TrimLeft(first, "# "))
+		text = strings.TrimSpace(rest)
+	}
+	if title == "" {
+		return "", fmt.Errorf("❌ %s has no title", file)
+	}
+	return title + "\n\n" + truncate(text, 600), nil
+}
+
+// changesText describes staged, unstaged and untracked work in one line so
+// the branch can be named after what you already started doing. The diff
+// only feeds the summary; it never becomes the description itself.
+func changesText(apiKey string) (string, error) {
+	stat, material, err := workInProgress()
+	if err != nil {
+		return "", err
+	}
+	summary, err := gemini.Narrate(apiKey, "", "",
+		"Say in one line of at most 60 characters what this work in progress does, the way you would describe a branch. "+
+			"No issue numbers, file contents or quotes.",
+		material)
+	if summary = strings.Trim(firstLine(summary), `"'`); err != nil || summary == "" {
+		return changedFilesSummary(stat), nil
+	}
+	return summary, nil
+}
+
+// workInProgress returns `git status --short` and prompt material made of
+// it plus a diff excerpt.
+func workInProgress() (stat, material string, err error) {
+	stat, _ = git.Output("status", "--short")
+	if strings.TrimSpace(stat) == "" {
+		return "", "", errors.New("❌ no changes to describe")
+	}
+	diff, derr := git.Output("diff", "HEAD")
+	if derr != nil {
+		// no commits yet: everything of interest is staged
+		diff, _ = git.Output("diff", "--cached")
+	}
+	return stat, "Work in progress. Changed files:\n" + stat + "\n\nDiff excerpt:\n" + truncate(diff, 2000), nil
+}
+
+// changedFilesSummary is the offline description: "update a.go, b.go and 3 more".
+func changedFilesSummary(status string) string {
+	var names []string
+	for _, l := range strings.Split(status, "\n") {
+		if f := strings.Fields(l); len(f) > 1 {
+			names = append(names, path.Base(f[len(f)-1]))
+		}
+	}
+	if len(names) > 3 {
+		names = append(names[:3], fmt.Sprintf("and %d more", len(names)-3))
+	}
+	return "update " + strings.Join(names, ", ")
+}
+
+// printBranchName is the --print mode: one suggestion, no prompts, and
+// nothing but the final name on stdout so $(gitr branch --print …) works.
+func printBranchName(apiKey, base string) error {
+	if strings.Contains(viper.GetString("branch_template"), "{type}") && brType == "" {
+		return errors.New("❌ branch_template uses {type}; pass --type with --print")
+	}
+	parts, err := gatherBranchParts(base)
+	if err != nil {
+		return err
+	}
+	length := "short"
+	if strings.ToLower(brLength) == "medium" {
+		length = "medium"
+	}
+	if parts.Slug, err = generateSlug(apiKey, base, pickPersona(), pickBranchMoodOnce(), length); err != nil {
+		return err
+	}
+	name, err := renderBranchName(parts)
+	if err != nil {
+		return err
+	}
+	if branchExists(name) {
+		name = nextFreeBranchName(name)
+	}
+	fmt.Println(name)
+	return nil
+}
+
 func promptBaseName() (string, error) {
-	fmt.Print("📝 Base branch description: ")
+	// stderr, so the prompt never ends up in a captured --print result
+	fmt.Fprint(os.Stderr, "📝 Base branch description: ")
 	in := bufio.NewReader(os.Stdin)
 	txt, err := in.ReadString('\n')
 	return strings.TrimSpace(txt), err
@@ -242,9 +446,104 @@ func slugify(in string) string {
 	return strings.Trim(s, "-")
 }
 
+// branchMeta is what gitr remembers about a branch it named.
+type branchMeta struct {
+	Description string
+	Persona     string
+	Mood        string
+}
+
+// finishBranch creates (or switches to) name after sorting out clashes with
+// existing branches. done is false when the user asked for a new suggestion.
+func finishBranch(name string, meta branchMeta) (done bool, err error) {
+	final, act, err := resolveCollision(name)
+	if err != nil {
+		return true, err
+	}
+	switch act {
+	case branchRegenerate:
+		return false, nil
+	case branchCancel:
+		fmt.Println("🚫 Aborted.")
+		return true, nil
+	case branchSwitch:
+		if err := git.Run("checkout", final); err != nil {
+			return true, err
+		}
+		fmt.Println("✅ Switched to existing branch!")
+		return true, nil
+	}
+	if err := checkoutBranch(final); err != nil {
+		return true, err
+	}
+	recordBranchMeta(final, meta)
+	if brNoCheckout {
+		fmt.Printf("✅ Created branch %s (still on your current one).\n", final)
+	} else {
+		fmt.Println("✅ Switched to new branch!")
+	}
+	return true, publishBranch(final)
+}
+
+// recordBranchMeta keeps the intent behind a punny name in git config:
+// branch.<name>.description (shown by git branch --edit-description and
+// format-patch) plus gitr's own branch.<name>.gitr* keys.
+func recordBranchMeta(name string, meta branchMeta) {
+	kv := [][2]string{
+		{"description", meta.Description},
+		{"gitrPersona", meta.Persona},
+		{"gitrMood", meta.Mood},
+	}
+	// a renamed branch keeps its birthday
+	if git.Config("branch."+name+".gitrCreated") == "" {
+		kv = append(kv, [2]string{"gitrCreated", strconv.FormatInt(time.Now().Unix(), 10)})
+	}
+	for _, e := range kv {
+		if e[1] == "" {
+			continue
+		}
+		if err := git.SetConfig("branch."+name+"."+e[0], e[1]); err != nil {
+			fmt.Printf("⚠️  could not store branch %s: %v\n", e[0], err)
+			return
+		}
+	}
+}
+
+// checkoutBranch creates name at --from (default HEAD) and, unless
+// --no-checkout was given, moves onto it with checkout -b or switch -c.
 func checkoutBranch(name string) error {
-	cmd := exec.Command("git", "checkout", "-b", name)
-	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
-	return cmd.Run()
+	var args []string
+	switch {
+	case brNoCheckout:
+		args = []string{"branch", name}
+	case brSwitch || viper.GetBool("branch_switch"):
+		args = []string{"switch", "-c", name}
+	default:
+		args = []string{"checkout", "-b", name}
+	}
+	if brFrom != "" {
+		args = append(args, brFrom)
+	}
+	return git.Run(args...)
+}
+
+// publishBranch pushes name and sets its upstream when --push or
+// --set-upstream asked for it.
+func publishBranch(name string) error {
+	if !brPush && brUpstream == "" {
+		return nil
+	}
+	remote := brUpstream
+	if remote == "" {
+		remote, _ = git.Output("config", "remote.pushDefault")
+	}
+	if remote == "" {
+		remote = "origin"
+	}
+	if err := git.Run("push", "--set-upstream", remote, name); err != nil {
+		return err
+	}
+	fmt.Printf("🚀 Published to %s/%s\n", remote, name)
+	return nil
 }
 
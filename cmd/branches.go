@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"git-randomizer/internal/git"
+
+	"github.com/spf13/cobra"
+)
+
+var bsAll bool
+
+var branchesCmd = &cobra.Command{
+	Use:   "branches",
+	Short: "List local branches with the description, persona and mood behind each name",
+	Args:  cobra.NoArgs,
+	RunE:  runBranches,
+}
+
+func init() {
+	branchesCmd.Flags().BoolVarP(&bsAll, "all", "a", false, "include branches gitr did not name")
+}
+
+/* ------------------- COMMAND ENTRY ------------------ */
+
+func runBranches(_ *cobra.Command, _ []string) error {
+	if _, err := git.GitDir(); err != nil {
+		return err
+	}
+	out, err := git.Output("for-each-ref", "--sort=-committerdate",
+		"--format=%(refname:short)%09%(committerdate:unix)", "refs/heads")
+	if err != nil {
+		return err
+	}
+	current := git.CurrentBranch()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  BRANCH\tPERSONA\tMOOD\tAGE\tDESCRIPTION")
+	shown := 0
+	for _, l := range strings.Split(out, "\n") {
+		name, tip, ok := strings.Cut(l, "\t")
+		if !ok {
+			continue
+		}
+		key := "branch." + name + "."
+		desc := git.Config(key + "description")
+		persona := git.Config(key + "gitrPersona")
+		if persona == "" && desc == "" && !bsAll {
+			continue
+		}
+
+		// age of the branch itself when gitr made it, else of its tip
+		created := git.Config(key + "gitrCreated")
+		if created == "" {
+			created = tip
+		}
+		secs, _ := strconv.ParseInt(created, 10, 64)
+
+		mark := " "
+		if name == current {
+			mark = "*"
+		}
+		fmt.Fprintf(w, "%s %s\t%s\t%s\t%s\t%s\n", mark, name, dash(persona),
+			dash(git.Config(key+"gitrMood")), age(time.Unix(secs, 0)), dash(firstLine(desc)))
+		shown++
+	}
+	if shown == 0 {
+		fmt.Println("ℹ️  no branches named by gitr yet (use --all to list every branch).")
+		return nil
+	}
+	return w.Flush()
+}
+
+/* -------------------- HELPERS --------------------- */
+
+func dash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// age renders the time since t the way a human would say it: 5m, 3h, 2d, 6w.
+func age(t time.Time) string {
+	d := time.Since(t)
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	case d < 14*24*time.Hour:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	case d < 365*24*time.Hour:
+		return fmt.Sprintf("%dw", int(d.Hours()/24/7))
+	default:
+		return fmt.Sprintf("%dy", int(d.Hours()/24/365))
+	}
+}
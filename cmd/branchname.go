@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"git-randomizer/internal/git"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/viper"
+)
+
+var (
+	placeholderRe = regexp.MustCompile(`\{(\w+)\}`)
+	ticketRe      = regexp.MustCompile(`\b[A-Z][A-Z0-9]+-\d+\b|#\d+\b`)
+	sepRunRe      = regexp.MustCompile(`[-_]{2,}`)
+	badRefRe      = regexp.MustCompile(`[\x00-\x20\x7f~^:?*\[\\]|@\{|\.{2,}`)
+)
+
+// branchParts holds the values a branch_template can refer to.
+type branchParts struct {
+	User   string
+	Type   string
+	Ticket string
+	Slug   string
+}
+
+// renderBranchName fills branch_template with p, trims every placeholder to
+// its configured length and asks git whether the result is a valid branch.
+func renderBranchName(p branchParts) (string, error) {
+	tmpl := viper.GetString("branch_template")
+	if tmpl == "" {
+		tmpl = "{slug}"
+	}
+	vals := map[string]string{
+		"user":   p.User,
+		"type":   p.Type,
+		"ticket": p.Ticket,
+		"slug":   p.Slug,
+	}
+	name := placeholderRe.ReplaceAllStringFunc(tmpl, func(ph string) string {
+		key := strings.Trim(ph, "{}")
+		return limitSegment(vals[key], segmentLimit(key))
+	})
+	name = tidyBranchName(name)
+
+	valid, err := git.Output("check-ref-format", "--branch", name)
+	if err != nil {
+		return "", fmt.Errorf("❌ %q is not a valid branch name", name)
+	}
+	return valid, nil
+}
+
+// gatherBranchParts collects everything except the slug, prompting for
+// {type} only when the template needs it and no --type was given.
+func gatherBranchParts(base string) (branchParts, error) {
+	tmpl := viper.GetString("branch_template")
+	var p branchParts
+
+	if strings.Contains(tmpl, "{user}") {
+		name, _ := git.Output("config", "user.name")
+		p.User = slugify(name)
+	}
+	if strings.Contains(tmpl, "{ticket}") {
+		p.Ticket = brTicket
+		if p.Ticket == "" {
+			p.Ticket = ticketRe.FindString(base)
+		}
+		p.Ticket = strings.TrimPrefix(p.Ticket, "#")
+	}
+	if strings.Contains(tmpl, "{type}") {
+		p.Type = brType
+		if p.Type == "" && brYes {
+			return p, errors.New("❌ branch_template uses {type}; pass --type with --yes or --from-issue -")
+		}
+		if p.Type == "" {
+			sel := promptui.Select{
+				Label:        "🏷️  Branch type",
+				Items:        viper.GetStringSlice("branch_types"),
+				HideSelected: true,
+			}
+			_, t, err := sel.Run()
+			if err != nil {
+				return p, err
+			}
+			p.Type = t
+		}
+	}
+	return p, nil
+}
+
+func segmentLimit(key string) int {
+	if n := viper.GetInt("branch_limits." + key); n > 0 {
+		return n
+	}
+	return 40
+}
+
+// limitSegment cuts s to n bytes, preferring a '-' boundary.
+func limitSegment(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	s = s[:n]
+	if i := strings.LastIndex(s, "-"); i > n/2 {
+		s = s[:i]
+	}
+	return strings.Trim(s, "-")
+}
+
+// tidyBranchName drops the separators left behind by empty placeholders
+// and anything git refuses in a ref (check-ref-format rules), so truncated
+// or templated names never end in ".lock", contain "..", and so on.
+func tidyBranchName(name string) string {
+	var parts []string
+	for _, seg := range strings.Split(name, "/") {
+		seg = badRefRe.ReplaceAllString(seg, "-")
+		seg = sepRunRe.ReplaceAllString(seg, "-")
+		for strings.HasSuffix(seg, ".lock") {
+			seg = strings.TrimSuffix(seg, ".lock")
+		}
+		if seg = strings.Trim(seg, "-_."); seg != "" {
+			parts = append(parts, seg)
+		}
+	}
+	name = strings.Join(parts, "/")
+	if name == "@" {
+		return ""
+	}
+	return name
+}
+
+/* ------------------- COLLISIONS --------------------- */
+
+type branchAction int
+
+const (
+	branchCreate branchAction = iota
+	branchSwitch
+	branchRegenerate
+	branchCancel
+)
+
+// resolveCollision checks name against local and remote-tracking branches.
+// On a clash it asks whether to add a suffix, regenerate, or switch to the
+// existing branch, and returns the name to act on.
+func resolveCollision(name string) (string, branchAction, error) {
+	if !branchExists(name) {
+		return name, branchCreate, nil
+	}
+	where := "locally"
+	if !git.RefExists("refs/heads/" + name) {
+		where = "on " + strings.Join(git.RemoteBranchesNamed(name), ", ")
+	}
+	suffixed := nextFreeBranchName(name)
+	fmt.Printf("⚠️  Branch %s already exists %s.\n", name, where)
+	if brYes {
+		return suffixed, branchCreate, nil
+	}
+
+	menu := promptui.Select{
+		Label: "❓ What now?",
+		Items: []string{
+			"Use " + suffixed,
+			"Generate another",
+			"Switch to existing " + name,
+			"Cancel",
+		},
+		HideSelected: true,
+	}
+	i, _, err := menu.Run()
+	if err == promptui.ErrInterrupt || err == promptui.ErrEOF {
+		return "", branchCancel, nil
+	} else if err != nil {
+		return "", branchCancel, err
+	}
+	switch i {
+	case 0:
+		return suffixed, branchCreate, nil
+	case 1:
+		return "", branchRegenerate, nil
+	case 2:
+		return name, branchSwitch, nil
+	default:
+		return "", branchCancel, nil
+	}
+}
+
+func branchExists(name string) bool {
+	return git.RefExists("refs/heads/"+name) || len(git.RemoteBranchesNamed(name)) > 0
+}
+
+func nextFreeBranchName(name string) string {
+	for n := 2; ; n++ {
+		if c := fmt.Sprintf("%s-%d", name, n); !branchExists(c) {
+			return c
+		}
+	}
+}
@@ -0,0 +1,350 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+	"time"
+
+	"git-randomizer/internal/conventional"
+	"git-randomizer/internal/gemini"
+	"git-randomizer/internal/git"
+	"git-randomizer/internal/notes"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+/* ---------------------- FLAGS ---------------------- */
+
+var (
+	clFormat  string
+	clPrepend string
+	clVersion string
+)
+
+var changelogCmd = &cobra.Command{
+	Use:   "changelog [<from>..<to>]",
+	Short: "Render persona-narrated release notes from git log",
+	Long: "Group the commits in <from>..<to> (default: last tag..HEAD) into Keep a Changelog\n" +
+		"sections by Conventional Commit type, and have a persona narrate each section.",
+	Args: cobra.MaximumNArgs(1),
+	RunE: runChangelog,
+}
+
+func init() {
+	addPersonaFlags(changelogCmd)
+	changelogCmd.Flags().StringVarP(&clFormat, "format", "f", "markdown", "markdown | json | text")
+	changelogCmd.Flags().StringVar(&clPrepend, "prepend", "", "prepend the Markdown to this file [=changelog.md]")
+	changelogCmd.Flags().Lookup("prepend").NoOptDefVal = "changelog.md"
+	changelogCmd.Flags().StringVarP(&clVersion, "version", "v", "Unreleased", "heading for this release")
+}
+
+// keepAChangelog is the section order of https://keepachangelog.com.
+var keepAChangelog = []string{"Added", "Changed", "Deprecated", "Removed", "Fixed", "Security"}
+
+type changeEntry struct {
+	Hash    string `json:"hash"`
+	Subject string `json:"subject"`
+	Type    string `json:"type,omitempty"`
+	Scope   string `json:"scope,omitempty"`
+}
+
+type changeSection struct {
+	Title     string        `json:"title"`
+	Narration string        `json:"narration"`
+	Commits   []changeEntry `json:"commits"`
+}
+
+type changelog struct {
+	Version  string          `json:"version"`
+	Date     string          `json:"date"`
+	Range    string          `json:"range"`
+	Persona  string          `json:"persona"`
+	Mood     string          `json:"mood"`
+	Sections []changeSection `json:"sections"`
+}
+
+/* ------------------- COMMAND ENTRY ------------------ */
+
+func runChangelog(_ *cobra.Command, args []string) error {
+	if _, err := git.GitDir(); err != nil {
+		return err
+	}
+	rng := defaultRange(args)
+	commits, err := git.Log("--no-merges", rng)
+	if err != nil {
+		return err
+	}
+	if len(commits) == 0 {
+		return fmt.Errorf("❌ no commits in %s", rng)
+	}
+	apiKey, err := getAPIKey(flagPass)
+	if err != nil {
+		return err
+	}
+
+	rand.Seed(time.Now().UnixNano())
+	cl := changelog{
+		Version: clVersion,
+		Date:    time.Now().Format("2006-01-02"),
+		Range:   rng,
+		Persona: pickStyle(),
+		Mood:    pickMoodOnce(),
+	}
+	grouped := groupChanges(commits)
+	for _, title := range keepAChangelog {
+		entries := grouped[title]
+		if len(entries) == 0 {
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "🎙️  %s narrates %s (%d)…\n", cl.Persona, title, len(entries))
+		text, err := gemini.Narrate(apiKey, cl.Persona, cl.Mood, fmt.Sprintf(
+			"Write the %q section of a changelog as a Markdown list: one '- ' bullet per change, one sentence each, no heading.",
+			title), changeMaterial(entries))
+		if err != nil {
+			return err
+		}
+		cl.Sections = append(cl.Sections, changeSection{Title: title, Narration: text, Commits: entries})
+	}
+
+	if clPrepend != "" {
+		if err := prependChangelog(clPrepend, cl); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "📝 Prepended %s to %s\n", cl.Version, clPrepend)
+		return nil
+	}
+	switch strings.ToLower(clFormat) {
+	case "json":
+		out, _ := json.MarshalIndent(cl, "", "  ")
+		fmt.Println(string(out))
+	case "text", "plain":
+		fmt.Print(cl.text())
+	default:
+		fmt.Print(cl.markdown())
+	}
+	return nil
+}
+
+/* -------------------- HELPERS --------------------- */
+
+// defaultRange turns the optional range argument into a git log range,
+// defaulting to everything since the last release tag.
+func defaultRange(args []string) string {
+	if len(args) == 1 {
+		if strings.Contains(args[0], "..") {
+			return args[0]
+		}
+		return args[0] + "..HEAD"
+	}
+	if tag, _, ok := lastRelease("HEAD", viper.GetString("release_prefix")); ok {
+		return tag + "..HEAD"
+	}
+	return "HEAD"
+}
+
+// groupChanges sorts commits into Keep a Changelog sections, reading the
+// sober original of persona commits wherever gitr recorded one.
+func groupChanges(commits []git.Commit) map[string][]changeEntry {
+	out := make(map[string][]changeEntry)
+	for i := len(commits) - 1; i >= 0; i-- { // oldest first
+		c := commits[i]
+		msg, _ := notes.Original(c.Hash, c.Message)
+		e := changeEntry{Hash: shortSHA(c.Hash), Subject: firstLine(msg)}
+		section := ""
+		if cc, ok := conventional.Parse(msg); ok {
+			e.Type, e.Scope, e.Subject = cc.Type, cc.Scope, cc.Description
+			section = sectionForType(cc.Type)
+		} else {
+			section = sectionByHeuristics(e.Subject, git.ChangedFiles(c.Hash))
+		}
+		out[section] = append(out[section], e)
+	}
+	return out
+}
+
+func sectionForType(t string) string {
+	switch strings.ToLower(t) {
+	case "feat", "feature":
+		return "Added"
+	case "fix", "bugfix", "hotfix":
+		return "Fixed"
+	case "revert", "remove":
+		return "Removed"
+	case "deprecate":
+		return "Deprecated"
+	case "security", "sec":
+		return "Security"
+	}
+	return "Changed"
+}
+
+// sectionByHeuristics guesses a section for free-form messages from the
+// leading verb, then from how the commit touched its paths.
+func sectionByHeuristics(subject string, changes [][2]string) string {
+	verb := strings.ToLower(strings.Trim(strings.SplitN(subject+" ", " ", 2)[0], ":.,"))
+	switch verb {
+	case "add", "adds", "added", "implement", "introduce", "create", "new":
+		return "Added"
+	case "fix", "fixes", "fixed", "resolve", "correct", "repair", "patch":
+		return "Fixed"
+	case "remove", "removes", "removed", "delete", "drop":
+		return "Removed"
+	case "deprecate", "deprecates", "deprecated":
+		return "Deprecated"
+	}
+	if strings.Contains(strings.ToLower(subject), "security") || strings.Contains(subject, "CVE-") {
+		return "Security"
+	}
+
+	// only new files → Added; only deletions → Removed
+	if len(changes) > 0 {
+		all := changes[0][0]
+		for _, c := range changes {
+			if c[0] != all {
+				all = ""
+			}
+		}
+		switch all {
+		case "A":
+			return "Added"
+		case "D":
+			return "Removed"
+		}
+	}
+	return "Changed"
+}
+
+func changeMaterial(entries []changeEntry) string {
+	var b strings.Builder
+	for _, e := range entries {
+		scope := ""
+		if e.Scope != "" {
+			scope = e.Scope + ": "
+		}
+		fmt.Fprintf(&b, "- %s%s (%s)\n", scope, e.Subject, e.Hash)
+	}
+	return b.String()
+}
+
+// unreleased reports whether cl is the Keep a Changelog "Unreleased"
+// section, which carries no date.
+func (cl changelog) unreleased() bool {
+	return strings.EqualFold(cl.Version, "Unreleased")
+}
+
+// entry renders the heading and sections in Keep a Changelog form.
+func (cl changelog) entry() string {
+	var b strings.Builder
+	if cl.unreleased() {
+		b.WriteString("## [Unreleased]\n")
+	} else {
+		fmt.Fprintf(&b, "## [%s] - %s\n", cl.Version, cl.Date)
+	}
+	for _, s := range cl.Sections {
+		fmt.Fprintf(&b, "### %s\n%s\n", s.Title, strings.TrimSpace(s.Narration))
+	}
+	return b.String()
+}
+
+func (cl changelog) markdown() string {
+	return cl.entry() + fmt.Sprintf("\n_Narrated by %s (%s)._\n", cl.Persona, cl.Mood)
+}
+
+func (cl changelog) text() string {
+	var b strings.Builder
+	head := fmt.Sprintf("%s (%s)", cl.Version, cl.Date)
+	fmt.Fprintf(&b, "%s\n%s\n", head, strings.Repeat("=", len([]rune(head))))
+	for _, s := range cl.Sections {
+		fmt.Fprintf(&b, "\n%s:\n", s.Title)
+		for _, l := range strings.Split(strings.TrimSpace(s.Narration), "\n") {
+			l = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(l), "-*"))
+			if l != "" {
+				fmt.Fprintf(&b, "  * %s\n", strings.ReplaceAll(l, "**", ""))
+			}
+		}
+	}
+	return b.String()
+}
+
+// prependChangelog adds cl to file (creating a Keep a Changelog skeleton
+// if the file is missing). Unreleased changes merge into an existing
+// [Unreleased] section; a version goes right below it, above the newest
+// release.
+func prependChangelog(file string, cl changelog) error {
+	raw, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		raw = []byte("# Changelog\n\nAll notable changes to this project will be documented in this file.\n\n---\n\n")
+	} else if err != nil {
+		return err
+	}
+	text := string(raw)
+
+	start, end, found := unreleasedSection(text)
+	var out string
+	switch {
+	case found && cl.unreleased():
+		out = text[:start] + mergeSections(text[start:end], cl.Sections) + text[end:]
+	case found:
+		out = text[:end] + cl.entry() + "\n" + text[end:]
+	default:
+		at := len(text)
+		if strings.HasPrefix(text, "## ") {
+			at = 0
+		} else if i := strings.Index(text, "\n## "); i >= 0 {
+			at = i + 1
+		}
+		out = text[:at] + cl.entry() + "\n" + text[at:]
+	}
+	return os.WriteFile(file, []byte(out), 0o644)
+}
+
+// unreleasedSection finds the "## [Unreleased]" block of text: from its
+// heading up to the next "## " heading.
+func unreleasedSection(text string) (start, end int, ok bool) {
+	lower := strings.ToLower(text)
+	start = strings.Index(lower, "\n## [unreleased]")
+	if strings.HasPrefix(lower, "## [unreleased]") {
+		start = 0
+	} else if start < 0 {
+		return 0, 0, false
+	} else {
+		start++
+	}
+	end = len(text)
+	if i := strings.Index(text[start+1:], "\n## "); i >= 0 {
+		end = start + 1 + i + 1
+	}
+	return start, end, true
+}
+
+// mergeSections appends each section's bullets under the matching "### "
+// heading of block, adding headings it does not have yet. Trailing blank
+// lines and a "---" rule stay at the end.
+func mergeSections(block string, sections []changeSection) string {
+	content := strings.TrimRight(block, "\n")
+	if strings.HasSuffix(content, "\n---") {
+		content = strings.TrimRight(strings.TrimSuffix(content, "---"), "\n")
+	}
+	tail := block[len(content):]
+
+	for _, s := range sections {
+		bullets := strings.TrimSpace(s.Narration)
+		head := "\n### " + s.Title + "\n"
+		i := strings.Index(content+"\n", head)
+		if i < 0 {
+			content += head + bullets
+			continue
+		}
+		at := len(content)
+		if j := strings.Index(content[i+len(head):], "\n### "); j >= 0 {
+			at = i + len(head) + j
+		}
+		content = strings.TrimRight(content[:at], "\n") + "\n" + bullets + content[at:]
+	}
+	return content + tail
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPrependChangelog(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "changelog.md")
+	seed := "# Changelog\n\n---\n\n## [Unreleased]\n### Changed\n- old change\n\n---\n\n## [1.0.0] - 2025-01-01\n### Added\n- first\n"
+	if err := os.WriteFile(file, []byte(seed), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cl := changelog{Version: "Unreleased", Date: "2026-10-19", Persona: "Pirate", Mood: "grumpy", Sections: []changeSection{
+		{Title: "Changed", Narration: "- new change\n"},
+		{Title: "Fixed", Narration: "- a fix\n"},
+	}}
+	if err := prependChangelog(file, cl); err != nil {
+		t.Fatal(err)
+	}
+	cl.Version, cl.Sections = "1.1.0", cl.Sections[1:]
+	if err := prependChangelog(file, cl); err != nil {
+		t.Fatal(err)
+	}
+
+	got, _ := os.ReadFile(file)
+	want := "# Changelog\n\n---\n\n## [Unreleased]\n### Changed\n- old change\n- new change\n### Fixed\n- a fix\n\n---\n\n" +
+		"## [1.1.0] - 2026-10-19\n### Fixed\n- a fix\n\n" +
+		"## [1.0.0] - 2025-01-01\n### Added\n- first\n"
+	if string(got) != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"errors"
 	"fmt"
+	"io"
 	"math/rand"
 	"os"
 	"os/exec"
@@ -11,6 +12,8 @@ import (
 	"time"
 
 	"git-randomizer/internal/gemini"
+	"git-randomizer/internal/git"
+	"git-randomizer/internal/notes"
 	"git-randomizer/internal/styles"
 
 	"github.com/manifoldco/promptui"
@@ -33,32 +36,82 @@ var (
 	flagSave       bool
 	flagTagline    string
 	flagNoTagline  bool
+	flagTrailers   bool
+	flagFile       string
+	flagEdit       bool
+
+	// forwarded verbatim to `git commit`
+	flagAll        bool
+	flagAmend      bool
+	flagSignoff    bool
+	flagGPGSign    string
+	flagNoVerify   bool
+	flagAuthor     string
+	flagDate       string
+	flagAllowEmpty bool
 )
 
+// gpgDefaultKey marks a bare --gpg-sign (no key id given).
+const gpgDefaultKey = "default"
+
 var commitCmd = &cobra.Command{
-	Use:   "commit",
+	Use:   "commit [flags] [-- pathspec...]",
 	Short: "Generate & apply a stylised git commit message",
 	RunE:  runCommit,
 }
 
 func init() {
-	commitCmd.Flags().StringVarP(&flagStyle, "style", "s", "", "persona style or 'random'")
-	commitCmd.Flags().BoolVarP(&flagRandom, "random", "r", false, "fully random persona")
-	commitCmd.Flags().StringVarP(&flagGroup, "group", "g", "", "random persona from this group")
-	commitCmd.Flags().StringVarP(&flagMood, "mood", "m", "", "mood or 'random'")
-	commitCmd.Flags().StringVarP(&flagLength, "length", "l", "", "short | medium | long")
-	commitCmd.Flags().BoolVarP(&flagYes, "yes", "y", false, "skip confirmation prompt")
-	commitCmd.Flags().StringVarP(&flagPass, "pass-secret", "p", "", "pass secret for GEMINI_API_KEY")
+	addPersonaFlags(commitCmd, withLength, withYes)
 	commitCmd.Flags().BoolVarP(&flagListStyles, "list-styles", "L", false, "list personas & exit")
 	commitCmd.Flags().BoolVarP(&flagListGroups, "list-groups", "G", false, "list persona groups & exit")
-	commitCmd.Flags().BoolVarP(&flagSave, "save", "S", false, "save current flags as defaults")
+	commitCmd.Flags().BoolVar(&flagSave, "save", false, "save current flags as defaults (was -S; -S now signs, as in git)")
 	commitCmd.Flags().StringVarP(&flagTagline, "tagline-style", "t", "", "persona for success tagline")
 	commitCmd.Flags().BoolVarP(&flagNoTagline, "no-tagline", "T", false, "suppress success tagline")
+	commitCmd.Flags().StringVarP(&flagFile, "file", "F", "", "read the message from a file ('-' for stdin)")
+	commitCmd.Flags().BoolVarP(&flagEdit, "edit", "e", false, "write the message in $EDITOR (subject + body)")
+	commitCmd.Flags().BoolVar(&flagTrailers, "trailers", false, "append Gitr-Persona/Gitr-Mood/Original-Message trailers")
+
+	// git commit pass-through; -S signs like git's, --save gave it up
+	commitCmd.Flags().BoolVarP(&flagAll, "all", "a", false, "git: stage modified/deleted files first")
+	commitCmd.Flags().BoolVar(&flagAmend, "amend", false, "git: amend the previous commit")
+	commitCmd.Flags().BoolVar(&flagSignoff, "signoff", false, "git: add Signed-off-by trailer")
+	commitCmd.Flags().StringVarP(&flagGPGSign, "gpg-sign", "S", "", "git: GPG-sign the commit [-S<keyid> | --gpg-sign=<keyid>]")
+	commitCmd.Flags().Lookup("gpg-sign").NoOptDefVal = gpgDefaultKey
+	commitCmd.Flags().BoolVarP(&flagNoVerify, "no-verify", "n", false, "git: bypass pre-commit and commit-msg hooks")
+	commitCmd.Flags().StringVar(&flagAuthor, "author", "", "git: override the commit author")
+	commitCmd.Flags().StringVar(&flagDate, "date", "", "git: override the author date")
+	commitCmd.Flags().BoolVar(&flagAllowEmpty, "allow-empty", false, "git: allow a commit with no changes")
+}
+
+// personaFlag names the optional members of the shared persona flag set.
+type personaFlag int
+
+const (
+	withLength personaFlag = iota // -l/--length
+	withYes                       // -y/--yes
+)
+
+// addPersonaFlags binds the persona selection flags every styling command
+// shares (-s, -r, -g, -m, -p) to cmd, plus whichever extras it supports.
+func addPersonaFlags(cmd *cobra.Command, extras ...personaFlag) {
+	cmd.Flags().StringVarP(&flagStyle, "style", "s", "", "persona style or 'random'")
+	cmd.Flags().BoolVarP(&flagRandom, "random", "r", false, "fully random persona")
+	cmd.Flags().StringVarP(&flagGroup, "group", "g", "", "random persona from this group")
+	cmd.Flags().StringVarP(&flagMood, "mood", "m", "", "mood or 'random'")
+	cmd.Flags().StringVarP(&flagPass, "pass-secret", "p", "", "pass secret for GEMINI_API_KEY")
+	for _, e := range extras {
+		switch e {
+		case withLength:
+			cmd.Flags().StringVarP(&flagLength, "length", "l", "", "short | medium | long")
+		case withYes:
+			cmd.Flags().BoolVarP(&flagYes, "yes", "y", false, "skip confirmation prompt")
+		}
+	}
 }
 
 /* ------------------- COMMAND ENTRY ------------------ */
 
-func runCommit(cmd *cobra.Command, _ []string) error {
+func runCommit(cmd *cobra.Command, args []string) error {
 	if flagListGroups {
 		fmt.Println("Available groups:")
 		for _, g := range styles.GroupNames() {
@@ -74,8 +127,8 @@ func runCommit(cmd *cobra.Command, _ []string) error {
 		return nil
 	}
 
-	if _, err := os.Stat(".git"); err != nil {
-		return errors.New("❌ not inside a git repository")
+	if _, err := git.TopLevel(); err != nil {
+		return err
 	}
 	apiKey, err := getAPIKey(flagPass)
 	if err != nil {
@@ -85,7 +138,7 @@ func runCommit(cmd *cobra.Command, _ []string) error {
 	rand.Seed(time.Now().UnixNano())
 	length := pickLength()
 
-	userMsg, err := promptCommitMessage()
+	userMsg, err := readCommitMessage()
 	if err != nil {
 		if err == promptui.ErrInterrupt || err == promptui.ErrEOF {
 			fmt.Println("\n🚫 Aborted.")
@@ -94,19 +147,23 @@ func runCommit(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
-	finalMsg, err := confirmFlow(userMsg, length, apiKey)
+	final, err := confirmFlow(userMsg, length, apiKey)
 	if err != nil {
 		return err
 	}
-	if finalMsg == "" {
+	if final.Message == "" {
 		fmt.Println("🚫 Aborted.")
 		return nil
 	}
+	if trailersEnabled(cmd) {
+		final.Message = withTrailers(final.Message, userMsg, final.Persona, final.Mood)
+	}
 
-	if err := gitCommit(finalMsg); err != nil {
+	if err := gitCommit(final.Message, gitCommitArgs(args)...); err != nil {
 		return err
 	}
 	fmt.Println("🎉 Git commit successful!")
+	recordOriginal("HEAD", userMsg, final)
 
 	if !flagNoTagline && viper.GetBool("tagline_enabled") {
 		tagPersona := taglinePersona()
@@ -195,6 +252,86 @@ func pickLength() string {
 	return viper.GetString("default_length")
 }
 
+// readCommitMessage takes the message from -F, $EDITOR, piped stdin or,
+// failing all that, a one-line prompt.
+func readCommitMessage() (string, error) {
+	var msg string
+	switch {
+	case flagFile == "-", flagFile == "" && !flagEdit && !isTerminal(os.Stdin):
+		raw, err := readStdin()
+		if err != nil {
+			return "", err
+		}
+		msg = raw
+	case flagFile != "":
+		raw, err := os.ReadFile(flagFile)
+		if err != nil {
+			return "", err
+		}
+		msg = string(raw)
+	case flagEdit:
+		return editMessage("")
+	default:
+		return promptCommitMessage()
+	}
+	if msg = strings.TrimSpace(msg); msg == "" {
+		return "", errors.New("❌ empty commit message")
+	}
+	return msg, nil
+}
+
+// readStdin drains stdin. Nothing is left for a confirm prompt to read
+// afterwards, so it implies --yes.
+func readStdin() (string, error) {
+	raw, err := io.ReadAll(os.Stdin)
+	flagYes = true
+	return string(raw), err
+}
+
+// editMessage opens git's configured editor on initial and returns the
+// result with #-comments stripped, the way `git commit` does.
+func editMessage(initial string) (string, error) {
+	editor, err := git.Output("var", "GIT_EDITOR")
+	if err != nil {
+		return "", err
+	}
+	f, err := os.CreateTemp("", "gitr-COMMIT_EDITMSG-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+	fmt.Fprintf(f, "%s\n\n# Write a subject line, a blank line, then the body.\n"+
+		"# gitr restyles it afterwards. Lines starting with '#' are ignored;\n"+
+		"# an empty message aborts.\n", initial)
+	f.Close()
+
+	c := exec.Command("sh", "-c", editor+` "$@"`, editor, f.Name())
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := c.Run(); err != nil {
+		return "", fmt.Errorf("❌ editor failed: %w", err)
+	}
+	raw, err := os.ReadFile(f.Name())
+	if err != nil {
+		return "", err
+	}
+	var kept []string
+	for _, l := range strings.Split(string(raw), "\n") {
+		if !strings.HasPrefix(l, "#") {
+			kept = append(kept, strings.TrimRight(l, " \t"))
+		}
+	}
+	msg := strings.TrimSpace(strings.Join(kept, "\n"))
+	if msg == "" {
+		return "", promptui.ErrEOF
+	}
+	return msg, nil
+}
+
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
 func promptCommitMessage() (string, error) {
 	fmt.Print("💬 Enter your commit message: ")
 	r := bufio.NewReader(os.Stdin)
@@ -204,7 +341,23 @@ func promptCommitMessage() (string, error) {
 
 /* ---------------- CONFIRMATION LOOP ---------------- */
 
-func confirmFlow(orig string, length string, apiKey string) (string, error) {
+// rewrite is what confirmFlow settled on. Persona is empty when the user
+// kept their original text; Message is empty when they cancelled.
+type rewrite struct {
+	Message string
+	Persona string
+	Mood    string
+}
+
+func confirmFlow(orig string, length string, apiKey string) (rewrite, error) {
+	return confirmLoop(orig, length, func(style, mood string) (string, error) {
+		return stylise(apiKey, style, mood, length, orig)
+	})
+}
+
+// confirmLoop is the generate → review → regenerate cycle shared by every
+// command that writes something in persona; gen produces one candidate.
+func confirmLoop(orig, length string, gen func(style, mood string) (string, error)) (rewrite, error) {
 	style := pickStyle()
 	mood := pickMoodOnce()
 	randomMood := moodIsRandomConfig()
@@ -212,7 +365,8 @@ func confirmFlow(orig string, length string, apiKey string) (string, error) {
 		(flagGroup != "" && flagStyle == "")
 
 	if flagYes || !viper.GetBool("confirm") {
-		return gemini.Generate(apiKey, style, mood, length, orig)
+		msg, err := gen(style, mood)
+		return rewrite{msg, style, mood}, err
 	}
 
 	for {
@@ -223,12 +377,12 @@ func confirmFlow(orig string, length string, apiKey string) (string, error) {
 			mood = styles.RandomMood()
 		}
 
-		gen, err := gemini.Generate(apiKey, style, mood, length, orig)
+		msg, err := gen(style, mood)
 		if err != nil {
-			return "", err
+			return rewrite{}, err
 		}
 		fmt.Printf("\n🧠 Generated commit message (%s, %s, %s):\n\n\"%s\"\n\n",
-			style, mood, length, gen)
+			style, mood, length, msg)
 
 		// first Y/n prompt
 		conf := promptui.Prompt{
@@ -239,21 +393,25 @@ func confirmFlow(orig string, length string, apiKey string) (string, error) {
 		ans, perr := conf.Run()
 		switch perr {
 		case promptui.ErrInterrupt, promptui.ErrEOF:
-			return "", nil
+			return rewrite{}, nil
 		case promptui.ErrAbort:
 			// typed "n" – fall through to menu
 		case nil:
 			if ans == "" || strings.ToLower(ans) == "y" {
-				return gen, nil
+				return rewrite{msg, style, mood}, nil
 			}
 		default:
-			return "", perr
+			return rewrite{}, perr
 		}
 
 		// secondary menu
+		items := []string{"Generate another", "Use my original", "Cancel"}
+		if orig == "" {
+			items = []string{"Generate another", "Cancel"} // nothing to fall back on
+		}
 		menu := promptui.Select{
 			Label:        "❓ What next?",
-			Items:        []string{"Generate another", "Use my original", "Cancel"},
+			Items:        items,
 			HideSelected: true,
 			Templates: &promptui.SelectTemplates{
 				Label:    "{{ . }}",
@@ -264,28 +422,107 @@ func confirmFlow(orig string, length string, apiKey string) (string, error) {
 		}
 		_, act, merr := menu.Run()
 		if merr == promptui.ErrInterrupt || merr == promptui.ErrEOF {
-			return "", nil
+			return rewrite{}, nil
 		} else if merr != nil {
-			return "", merr
+			return rewrite{}, merr
 		}
 
 		switch act {
 		case "Generate another":
 			continue
 		case "Use my original":
-			return orig, nil
+			return rewrite{Message: orig}, nil
 		default: // Cancel
-			return "", nil
+			return rewrite{}, nil
 		}
 	}
 }
 
 /* ---------------- GIT EXEC & SAVE ---------------- */
 
-func gitCommit(msg string) error {
-	cmd := exec.Command("git", "commit", "-m", msg)
-	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
-	return cmd.Run()
+func gitCommit(msg string, extra ...string) error {
+	return git.Run(append([]string{"commit", "-m", msg}, extra...)...)
+}
+
+// recordOriginal keeps the pre-persona message in refs/notes/gitr. A failure
+// here never undoes the commit, it only warns.
+func recordOriginal(rev, orig string, r rewrite) {
+	if r.Persona == "" {
+		return
+	}
+	err := notes.Write(rev, notes.Record{
+		Persona:  r.Persona,
+		Mood:     r.Mood,
+		Model:    gemini.Model,
+		Time:     time.Now(),
+		Original: orig,
+	})
+	if err != nil {
+		fmt.Printf("⚠️  could not store original message in notes: %v\n", err)
+	}
+}
+
+func trailersEnabled(cmd *cobra.Command) bool {
+	if cmd.Flags().Changed("trailers") {
+		return flagTrailers
+	}
+	return viper.GetBool("trailers")
+}
+
+// withTrailers appends the Gitr-* trailers to msg. Kept originals (no
+// persona) are left alone, and a failing git only costs the trailers.
+func withTrailers(msg, orig, persona, mood string) string {
+	if persona == "" {
+		return msg
+	}
+	out, err := git.AddTrailers(msg, "replace", [][2]string{
+		{notes.TrailerPersona, persona},
+		{notes.TrailerMood, mood},
+		{notes.TrailerOriginal, strings.Join(strings.Fields(orig), " ")},
+	})
+	if err != nil {
+		fmt.Printf("⚠️  could not add trailers: %v\n", err)
+		return msg
+	}
+	return out
+}
+
+// gitCommitArgs turns the pass-through flags and pathspecs into git arguments.
+func gitCommitArgs(pathspecs []string) []string {
+	var out []string
+	if flagAll {
+		out = append(out, "--all")
+	}
+	if flagAmend {
+		out = append(out, "--amend")
+	}
+	if flagSignoff {
+		out = append(out, "--signoff")
+	}
+	switch flagGPGSign {
+	case "":
+	case gpgDefaultKey:
+		out = append(out, "--gpg-sign")
+	default:
+		out = append(out, "--gpg-sign="+flagGPGSign)
+	}
+	if flagNoVerify {
+		out = append(out, "--no-verify")
+	}
+	if flagAuthor != "" {
+		out = append(out, "--author="+flagAuthor)
+	}
+	if flagDate != "" {
+		out = append(out, "--date="+flagDate)
+	}
+	if flagAllowEmpty {
+		out = append(out, "--allow-empty")
+	}
+	if len(pathspecs) > 0 {
+		out = append(out, "--")
+		out = append(out, pathspecs...)
+	}
+	return out
 }
 
 func taglinePersona() string {
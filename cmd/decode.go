@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"git-randomizer/internal/conventional"
+	"git-randomizer/internal/gemini"
+	"git-randomizer/internal/git"
+	"git-randomizer/internal/notes"
+
+	"github.com/spf13/cobra"
+)
+
+/* ---------------------- FLAGS ---------------------- */
+
+var (
+	dcWrite bool
+	dcAll   bool
+	dcPass  string
+)
+
+var decodeCmd = &cobra.Command{
+	Use:   "decode [<rev> | <from>..<to>]",
+	Short: "Translate persona commit messages back to plain English",
+	Long: "For commits whose stylised message has no recorded original, ask the model for\n" +
+		"a concise Conventional Commit rewrite and print old → new. With --write the\n" +
+		"rewrite is stored in refs/notes/gitr; history itself is never touched.",
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDecode,
+}
+
+func init() {
+	decodeCmd.Flags().BoolVarP(&dcWrite, "write", "w", false, "store the rewrites as gitr notes")
+	decodeCmd.Flags().BoolVarP(&dcAll, "all", "a", false, "also decode messages that already look conventional")
+	decodeCmd.Flags().StringVarP(&dcPass, "pass-secret", "p", "", "pass secret for GEMINI_API_KEY")
+}
+
+/* ------------------- COMMAND ENTRY ------------------ */
+
+func runDecode(_ *cobra.Command, args []string) error {
+	if _, err := git.GitDir(); err != nil {
+		return err
+	}
+	rev := "HEAD"
+	if len(args) == 1 {
+		rev = args[0]
+	}
+	var commits []string
+	if strings.Contains(rev, "..") {
+		list, err := git.RevList("--reverse", rev)
+		if err != nil {
+			return err
+		}
+		commits = list
+	} else {
+		sha, err := git.Output("rev-parse", "--verify", "-q", rev+"^{commit}")
+		if err != nil {
+			return fmt.Errorf("❌ unknown revision %q", rev)
+		}
+		commits = []string{sha}
+	}
+	if len(commits) == 0 {
+		return fmt.Errorf("❌ %s selects no commits", rev)
+	}
+
+	apiKey, err := getAPIKey(dcPass)
+	if err != nil {
+		return err
+	}
+	var rows [][3]string
+	decoded := make(map[string]string)
+	recorded, skipped := 0, 0
+	for _, sha := range commits {
+		cur, err := git.Message(sha)
+		if err != nil {
+			return err
+		}
+		if _, ok := notes.Original(sha, cur); ok {
+			recorded++
+			continue
+		}
+		cur = stripGitrTrailers(cur)
+		if _, ok := conventional.Parse(cur); ok && !dcAll {
+			skipped++
+			continue
+		}
+		fmt.Printf("🔎 decoding %s…\n", shortSHA(sha))
+		plain, err := gemini.Plain(apiKey, cur)
+		if err != nil {
+			return err
+		}
+		decoded[sha] = plain
+		rows = append(rows, [3]string{shortSHA(sha), firstLine(cur), firstLine(plain)})
+	}
+
+	if recorded > 0 {
+		fmt.Printf("ℹ️  %d commits already have a recorded original (see gitr show).\n", recorded)
+	}
+	if skipped > 0 {
+		fmt.Printf("ℹ️  %d commits already read as Conventional Commits (use --all to decode them anyway).\n", skipped)
+	}
+	if len(rows) == 0 {
+		fmt.Println("✨ Nothing to decode.")
+		return nil
+	}
+	printReviewTable([3]string{"COMMIT", "PERSONA", "DECODED"}, rows)
+	if !dcWrite {
+		return nil
+	}
+
+	// persona and mood are unknown; the record only carries the meaning,
+	// marked as decoded so nobody mistakes it for what was really written
+	for _, sha := range commits {
+		plain, ok := decoded[sha]
+		if !ok {
+			continue
+		}
+		if err := notes.Write(sha, notes.Record{Model: gemini.Model, Time: time.Now(), Source: notes.SourceDecoded, Original: plain}); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("📝 Wrote %d notes to refs/notes/%s. Share them with: git push origin refs/notes/%s\n",
+		len(decoded), notes.Ref, notes.Ref)
+	return nil
+}
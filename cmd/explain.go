@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+	"time"
+
+	"git-randomizer/internal/gemini"
+	"git-randomizer/internal/git"
+	"git-randomizer/internal/notes"
+
+	"github.com/spf13/cobra"
+)
+
+/* ---------------------- FLAGS ---------------------- */
+
+var exSerious bool
+
+var explainCmd = &cobra.Command{
+	Use:   "explain [rev]",
+	Short: "Have a persona explain what a commit changed and why",
+	Long: "Feed a commit's message, diffstat and (trimmed) diff to the model and print an\n" +
+		"explanation in persona. --serious gives a plain one for reviewers.",
+	Args: cobra.MaximumNArgs(1),
+	RunE: runExplain,
+}
+
+func init() {
+	addPersonaFlags(explainCmd)
+	explainCmd.Flags().BoolVar(&exSerious, "serious", false, "plain, professional explanation")
+}
+
+// explainDiffLimit caps how much diff goes into the prompt; the diffstat
+// still covers every file.
+const explainDiffLimit = 12000
+
+/* ------------------- COMMAND ENTRY ------------------ */
+
+func runExplain(_ *cobra.Command, args []string) error {
+	if _, err := git.GitDir(); err != nil {
+		return err
+	}
+	rev := "HEAD"
+	if len(args) == 1 {
+		rev = args[0]
+	}
+	sha, err := git.Output("rev-parse", "--verify", "-q", rev+"^{commit}")
+	if err != nil {
+		return fmt.Errorf("❌ unknown revision %q", rev)
+	}
+	msg, err := git.Message(sha)
+	if err != nil {
+		return err
+	}
+	msg, _ = notes.Original(sha, msg)
+	stat, _ := git.Output("show", "--stat", "--format=", sha)
+	diff, _ := git.Output("show", "--format=", "--no-color", "--no-ext-diff", sha)
+
+	apiKey, err := getAPIKey(flagPass)
+	if err != nil {
+		return err
+	}
+	persona, mood := "", ""
+	if !exSerious {
+		rand.Seed(time.Now().UnixNano())
+		persona, mood = pickStyle(), pickMoodOnce()
+		fmt.Fprintf(os.Stderr, "🧙 %s is reading %s (%s)…\n", persona, shortSHA(sha), mood)
+	}
+
+	material := fmt.Sprintf("Message:\n%s\n\nDiffstat:\n%s\n\nDiff:\n%s", msg, stat, trimDiff(diff, explainDiffLimit))
+	out, err := gemini.Narrate(apiKey, persona, mood,
+		"Explain this commit to a developer new to the codebase: what changed, why, and what to watch out for. "+
+			"Two or three short paragraphs, mention the important files by path.",
+		material)
+	if err != nil {
+		return err
+	}
+	head := "📖 " + shortSHA(sha) + " " + firstLine(msg)
+	if persona != "" {
+		head += fmt.Sprintf("\n🎭 %s, %s", persona, mood)
+	}
+	fmt.Printf("%s\n\n%s\n", head, strings.TrimSpace(out))
+	return nil
+}
+
+/* -------------------- HELPERS --------------------- */
+
+// trimDiff cuts diff to at most limit bytes at a line boundary and says
+// so, so the model does not mistake a truncated hunk for the real change.
+func trimDiff(diff string, limit int) string {
+	if len(diff) <= limit {
+		return diff
+	}
+	cut := diff[:limit]
+	if i := strings.LastIndexByte(cut, '\n'); i > 0 {
+		cut = cut[:i]
+	}
+	return cut + fmt.Sprintf("\n[… diff truncated, %d more bytes]", len(diff)-len(cut))
+}
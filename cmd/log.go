@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"git-randomizer/internal/git"
+	"git-randomizer/internal/notes"
+	"git-randomizer/internal/styles"
+
+	"github.com/spf13/cobra"
+)
+
+/* ---------------------- FLAGS ---------------------- */
+
+var (
+	lgPersona  string
+	lgGroup    string
+	lgMood     string
+	lgOriginal bool
+	lgMax      int
+)
+
+var logCmd = &cobra.Command{
+	Use:   "log [<revision range>] [-- <path>...]",
+	Short: "git log with the persona, mood and original message behind each commit",
+	Long: "Read-only log viewer: every commit gitr styled gets a coloured badge for its\n" +
+		"persona group plus the original message from refs/notes/gitr (or trailers).\n" +
+		"Arguments are passed to git log as-is.",
+	RunE: runLog,
+}
+
+func init() {
+	logCmd.Flags().StringVar(&lgPersona, "persona", "", "only commits styled by this persona")
+	logCmd.Flags().StringVarP(&lgGroup, "group", "g", "", "only commits whose persona is in this group")
+	logCmd.Flags().StringVarP(&lgMood, "mood", "m", "", "only commits written in this mood")
+	logCmd.Flags().BoolVarP(&lgOriginal, "original", "o", false, "show the original messages instead")
+	logCmd.Flags().IntVarP(&lgMax, "max-count", "n", 0, "stop after this many (matching) commits")
+}
+
+// badgeColours are cycled over styles.GroupNames() so a group keeps its
+// colour from one run to the next.
+var badgeColours = []string{"31", "32", "33", "34", "35", "36", "91", "92", "93", "94", "95", "96"}
+
+/* ------------------- COMMAND ENTRY ------------------ */
+
+func runLog(cmd *cobra.Command, args []string) error {
+	if _, err := git.GitDir(); err != nil {
+		return err
+	}
+	if lgGroup != "" {
+		if _, ok := styles.Groups[strings.ToLower(lgGroup)]; !ok {
+			return fmt.Errorf("❌ unknown group %q (see gitr -G)", lgGroup)
+		}
+	}
+	// cobra eats the "--" before pathspecs; git log needs it back
+	if at := cmd.ArgsLenAtDash(); at >= 0 {
+		args = append(args[:at:at], append([]string{"--"}, args[at:]...)...)
+	}
+	// without filters every commit is shown, so git can stop early itself
+	if lgMax > 0 && lgPersona == "" && lgGroup == "" && lgMood == "" {
+		args = append([]string{"--max-count=" + strconv.Itoa(lgMax)}, args...)
+	}
+	commits, err := git.LogNotes(notes.Ref, args...)
+	if err != nil {
+		return err
+	}
+	colour := isTerminal(os.Stdout) && os.Getenv("NO_COLOR") == ""
+
+	shown := 0
+	for _, c := range commits {
+		rec, styled := notes.Parse(c.Note)
+		hasTrailers := hasGitrTrailers(c.Message)
+		if !styled && hasTrailers {
+			rec, styled = notes.FromTrailers(c.Message)
+		}
+		group := styles.GroupOf(rec.Persona)
+		if !logMatches(rec, group) {
+			continue
+		}
+		if lgMax > 0 && shown == lgMax {
+			break
+		}
+		shown++
+
+		fmt.Printf("%s %s  %s  %s\n", paint(colour, "33", shortSHA(c.Hash)),
+			logBadge(colour, rec, group, styled), c.Date.Format("2006-01-02"), c.Author)
+		msg := c.Message
+		if styled && rec.Original != "" && lgOriginal {
+			msg = rec.Original
+		}
+		if hasTrailers {
+			msg = stripGitrTrailers(msg)
+		}
+		for _, l := range strings.Split(msg, "\n") {
+			fmt.Println(strings.TrimRight("    "+l, " "))
+		}
+		switch {
+		case !styled || rec.Original == "":
+		case !lgOriginal && rec.Decoded():
+			fmt.Printf("    %s\n", paint(colour, "2", "↳ (decoded guess) "+firstLine(rec.Original)))
+		case !lgOriginal:
+			fmt.Printf("    %s\n", paint(colour, "2", "↳ "+firstLine(rec.Original)))
+		case rec.Decoded():
+			fmt.Printf("    %s\n", paint(colour, "2", "↳ decoded guess, not the recorded original"))
+		}
+		fmt.Println()
+	}
+	if shown == 0 && (lgPersona != "" || lgGroup != "" || lgMood != "") {
+		fmt.Println("ℹ️  no commits match those filters.")
+	}
+	return nil
+}
+
+/* -------------------- HELPERS --------------------- */
+
+// hasGitrTrailers is a cheap pre-check so plain commits never cost an
+// interpret-trailers run.
+func hasGitrTrailers(msg string) bool {
+	lower := strings.ToLower(msg)
+	for _, k := range []string{notes.TrailerPersona, notes.TrailerMood, notes.TrailerOriginal} {
+		if strings.Contains(lower, "\n"+strings.ToLower(k)+":") {
+			return true
+		}
+	}
+	return false
+}
+
+func logMatches(rec notes.Record, group string) bool {
+	switch {
+	case lgPersona != "" && !strings.EqualFold(rec.Persona, lgPersona):
+		return false
+	case lgGroup != "" && !strings.EqualFold(group, lgGroup):
+		return false
+	case lgMood != "" && !strings.EqualFold(rec.Mood, lgMood):
+		return false
+	}
+	return true
+}
+
+// logBadge renders "[group] persona · mood", coloured per group; commits
+// gitr never touched get a plain "[plain]".
+func logBadge(colour bool, rec notes.Record, group string, styled bool) string {
+	if !styled || rec.Persona == "" {
+		return paint(colour, "2", "[plain]")
+	}
+	code := "37"
+	if group == "" {
+		group = "custom"
+	} else {
+		for i, g := range styles.GroupNames() {
+			if g == group {
+				code = badgeColours[i%len(badgeColours)]
+			}
+		}
+	}
+	badge := fmt.Sprintf("[%s] %s", group, rec.Persona)
+	if rec.Mood != "" {
+		badge += " · " + rec.Mood
+	}
+	return paint(colour, "1;"+code, badge)
+}
+
+// paint wraps s in an ANSI SGR sequence when colour is on.
+func paint(colour bool, code, s string) string {
+	if !colour {
+		return s
+	}
+	return "\x1b[" + code + "m" + s + "\x1b[0m"
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+	"time"
+
+	"git-randomizer/internal/gemini"
+	"git-randomizer/internal/git"
+	"git-randomizer/internal/notes"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+/* ---------------------- FLAGS ---------------------- */
+
+var (
+	prBase   string
+	prOutput string
+)
+
+var prCmd = &cobra.Command{
+	Use:   "pr",
+	Short: "Draft a pull-request title and body from the branch's commits",
+	Long: "Collect the commits since the merge base with the default branch and draft a PR\n" +
+		"title and Markdown body in persona, with a serious summary for reviewers.\n" +
+		"Use -o to write the body for `gh pr create -F`.",
+	Args: cobra.NoArgs,
+	RunE: runPR,
+}
+
+func init() {
+	addPersonaFlags(prCmd)
+	prCmd.Flags().StringVarP(&prBase, "base", "b", "", "branch the PR targets (default: pr_base or origin/HEAD)")
+	prCmd.Flags().StringVarP(&prOutput, "output", "o", "", "write the body to this file instead of stdout")
+}
+
+/* ------------------- COMMAND ENTRY ------------------ */
+
+func runPR(_ *cobra.Command, _ []string) error {
+	if _, err := git.GitDir(); err != nil {
+		return err
+	}
+	base := prBase
+	if base == "" {
+		base = defaultBaseBranch()
+	}
+	mb, err := git.Output("merge-base", base, "HEAD")
+	if err != nil {
+		return fmt.Errorf("❌ no merge base between %s and HEAD", base)
+	}
+	commits, err := git.Log("--no-merges", mb+"..HEAD")
+	if err != nil {
+		return err
+	}
+	if len(commits) == 0 {
+		return fmt.Errorf("❌ HEAD has no commits on top of %s", base)
+	}
+	stat, _ := git.Output("diff", "--stat", mb, "HEAD")
+
+	apiKey, err := getAPIKey(flagPass)
+	if err != nil {
+		return err
+	}
+	rand.Seed(time.Now().UnixNano())
+	persona, mood := pickStyle(), pickMoodOnce()
+
+	var material, list strings.Builder
+	for i := len(commits) - 1; i >= 0; i-- { // oldest first
+		c := commits[i]
+		msg, _ := notes.Original(c.Hash, c.Message)
+		fmt.Fprintf(&material, "commit %s\n%s\n\n", shortSHA(c.Hash), msg)
+		fmt.Fprintf(&list, "- %s %s\n", shortSHA(c.Hash), firstLine(msg))
+	}
+	material.WriteString("Diffstat:\n" + stat)
+
+	fmt.Fprintf(os.Stderr, "✍️  %s is drafting your PR (%s)…\n", persona, mood)
+	title, err := gemini.Narrate(apiKey, persona, mood,
+		"Write a pull request title of at most 72 characters. Respond with the title only.", material.String())
+	if err != nil {
+		return err
+	}
+	summary, err := gemini.Narrate(apiKey, "", "",
+		"Summarise this pull request for code reviewers as three to six '- ' bullets: what changed, why, and anything risky.",
+		material.String())
+	if err != nil {
+		return err
+	}
+	pitch, err := gemini.Narrate(apiKey, persona, mood,
+		"Pitch this pull request to its reviewers in one short paragraph.", material.String())
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("## Summary\n\n%s\n\n## In character\n\n> %s\n>\n> — *%s*\n\n## Commits\n\n%s\n## Diffstat\n\n```\n%s\n```\n",
+		strings.TrimSpace(summary),
+		strings.ReplaceAll(strings.TrimSpace(pitch), "\n", "\n> "),
+		persona, list.String(), stat)
+	title = strings.Trim(firstLine(title), `"'`)
+
+	if prOutput == "" {
+		fmt.Printf("%s\n\n%s", title, body)
+		return nil
+	}
+	if err := os.WriteFile(prOutput, []byte(body), 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("📝 Body written to %s\n\n  gh pr create --base %s --title %q -F %s\n",
+		prOutput, strings.TrimPrefix(base, "origin/"), title, prOutput)
+	return nil
+}
+
+/* -------------------- HELPERS --------------------- */
+
+// defaultBaseBranch is pr_base from config, else what origin/HEAD points
+// at, else the first of main/master that exists.
+func defaultBaseBranch() string {
+	if b := viper.GetString("pr_base"); b != "" {
+		return b
+	}
+	if b, err := git.Output("symbolic-ref", "--short", "-q", "refs/remotes/origin/HEAD"); err == nil && b != "" {
+		return b
+	}
+	for _, b := range []string{"main", "master"} {
+		if git.RefExists("refs/heads/" + b) {
+			return b
+		}
+	}
+	return "main"
+}
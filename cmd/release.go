@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"git-randomizer/internal/conventional"
+	"git-randomizer/internal/gemini"
+	"git-randomizer/internal/git"
+	"git-randomizer/internal/notes"
+	"git-randomizer/internal/semver"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+/* ---------------------- FLAGS ---------------------- */
+
+var (
+	relDryRun bool
+	relPrefix string
+	relBump   string
+)
+
+var releaseCmd = &cobra.Command{
+	Use:   "release",
+	Short: "Suggest the next semver tag, name it in persona and create it",
+	Args:  cobra.NoArgs,
+	RunE:  runRelease,
+}
+
+func init() {
+	addPersonaFlags(releaseCmd, withYes)
+	releaseCmd.Flags().BoolVarP(&relDryRun, "dry-run", "d", false, "show the plan, create nothing")
+	releaseCmd.Flags().StringVar(&relPrefix, "prefix", "", "tag prefix (default: release_prefix from config)")
+	releaseCmd.Flags().StringVarP(&relBump, "bump", "b", "", "override the computed bump: major | minor | patch")
+}
+
+/* ------------------- COMMAND ENTRY ------------------ */
+
+func runRelease(cmd *cobra.Command, _ []string) error {
+	if _, err := git.GitDir(); err != nil {
+		return err
+	}
+	prefix := viper.GetString("release_prefix")
+	if cmd.Flags().Changed("prefix") {
+		prefix = relPrefix
+	}
+
+	last, current, _ := lastRelease("HEAD", prefix)
+	rng := "HEAD"
+	if last != "" {
+		rng = last + "..HEAD"
+	}
+	commits, err := git.Log("--no-merges", rng)
+	if err != nil {
+		return err
+	}
+	if len(commits) == 0 {
+		return fmt.Errorf("❌ nothing to release: no commits since %s", last)
+	}
+
+	bump, counts := suggestBump(commits)
+	if relBump != "" {
+		switch b := strings.ToLower(relBump); b {
+		case semver.Major, semver.Minor, semver.Patch:
+			bump = b
+		default:
+			return errors.New("❌ --bump must be major, minor or patch")
+		}
+	}
+	tag := prefix + current.Bump(bump).String()
+	if git.RefExists("refs/tags/" + tag) {
+		return fmt.Errorf("❌ tag %s already exists", tag)
+	}
+
+	fmt.Printf("📦 %d commits since %s: %d breaking, %d feat, %d fix, %d other → %s bump\n",
+		len(commits), dash(last), counts[0], counts[1], counts[2], counts[3], bump)
+
+	apiKey, err := getAPIKey(flagPass)
+	if err != nil {
+		return err
+	}
+	rand.Seed(time.Now().UnixNano())
+	material := releaseMaterial(commits)
+
+	for {
+		persona, mood := pickStyle(), pickMoodOnce()
+		codename, err := gemini.Narrate(apiKey, persona, mood,
+			"Invent a codename of two to four words for this software release. Respond with the codename only, no quotes.",
+			material)
+		if err != nil {
+			return err
+		}
+		codename = strings.Trim(firstLine(codename), `"'“”`)
+		body, err := gemini.Narrate(apiKey, persona, mood,
+			"Write the body of an annotated git tag for this release: two to five '- ' bullets with the highlights.",
+			material)
+		if err != nil {
+			return err
+		}
+		msg := fmt.Sprintf("%s “%s”\n\n%s", tag, codename, strings.TrimSpace(body))
+		fmt.Printf("\n🏷️  %s (%s, %s)\n\n%s\n\n", tag, persona, mood, msg)
+
+		if relDryRun {
+			fmt.Println("🔍 Dry run: no tag created.")
+			return nil
+		}
+		if !flagYes {
+			menu := promptui.Select{
+				Label:        "❓ Create this tag?",
+				Items:        []string{"Create tag", "Generate another", "Cancel"},
+				HideSelected: true,
+			}
+			_, act, err := menu.Run()
+			if err != nil || act == "Cancel" {
+				fmt.Println("🚫 Aborted.")
+				return nil
+			}
+			if act == "Generate another" {
+				continue
+			}
+		}
+		if err := git.Run("tag", "-a", tag, "-m", msg); err != nil {
+			return err
+		}
+		fmt.Printf("🎉 Tagged %s. Push it with: git push origin %s\n", tag, tag)
+		return nil
+	}
+}
+
+/* -------------------- HELPERS --------------------- */
+
+// suggestBump applies the usual Conventional Commits rules: any breaking
+// change → major, any feat → minor, otherwise patch. counts holds
+// breaking, feat, fix and other commits in that order.
+func suggestBump(commits []git.Commit) (string, [4]int) {
+	var counts [4]int
+	for _, c := range commits {
+		msg, _ := notes.Original(c.Hash, c.Message)
+		cc, ok := conventional.Parse(msg)
+		switch {
+		case ok && (cc.Breaking || hasBreakingFooter(cc)):
+			counts[0]++
+		case ok && strings.EqualFold(cc.Type, "feat"):
+			counts[1]++
+		case ok && strings.EqualFold(cc.Type, "fix"):
+			counts[2]++
+		default:
+			counts[3]++
+		}
+	}
+	switch {
+	case counts[0] > 0:
+		return semver.Major, counts
+	case counts[1] > 0:
+		return semver.Minor, counts
+	}
+	return semver.Patch, counts
+}
+
+func hasBreakingFooter(cc conventional.Message) bool {
+	for _, f := range cc.Footers {
+		if strings.HasPrefix(f, "BREAKING CHANGE") || strings.HasPrefix(f, "BREAKING-CHANGE") {
+			return true
+		}
+	}
+	return false
+}
+
+// lastRelease is the highest <prefix>MAJOR.MINOR.PATCH tag reachable from
+// rev; codename, RC and other hand-made tags never count.
+func lastRelease(rev, prefix string) (string, semver.Version, bool) {
+	return semver.Latest(git.TagsMerged(rev, prefix+"[0-9]*"), prefix)
+}
+
+func releaseMaterial(commits []git.Commit) string {
+	var b strings.Builder
+	for _, c := range commits {
+		msg, _ := notes.Original(c.Hash, c.Message)
+		fmt.Fprintf(&b, "- %s\n", firstLine(msg))
+	}
+	return b.String()
+}
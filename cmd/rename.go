@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"git-randomizer/internal/git"
+	"git-randomizer/internal/styles"
+
+	"github.com/manifoldco/promptui"
+)
+
+// runBranchRename is `gitr branch --rename [branch]`: give an existing
+// branch (default: the current one) a persona name via git branch -m.
+func runBranchRename(apiKey string, args []string) error {
+	old := git.CurrentBranch()
+	if len(args) > 0 {
+		old = args[0]
+	}
+	if old == "" {
+		return errors.New("❌ detached HEAD: name the branch to rename")
+	}
+	if !git.RefExists("refs/heads/" + old) {
+		return fmt.Errorf("❌ no local branch %q", old)
+	}
+
+	// what the branch is about: what gitr stored, else its own name
+	base := git.Config("branch." + old + ".description")
+	if base == "" {
+		base = strings.NewReplacer("-", " ", "_", " ").Replace(path.Base(old))
+	}
+	parts, err := gatherBranchParts(base)
+	if err == promptui.ErrInterrupt || err == promptui.ErrEOF {
+		fmt.Println("\n🚫 Aborted.")
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	persona := pickPersona()
+	mood := pickBranchMoodOnce()
+	length := "short"
+	if strings.ToLower(brLength) == "medium" {
+		length = "medium"
+	}
+
+	for {
+		if parts.Slug, err = generateSlug(apiKey, base, persona, mood, length); err != nil {
+			return err
+		}
+		name, err := renderBranchName(parts)
+		if err != nil {
+			return err
+		}
+		if name != old && branchExists(name) {
+			name = nextFreeBranchName(name)
+		}
+		fmt.Printf("\n🌿 Rename (%s, %s): %s → %s\n\n", persona, mood, old, name)
+
+		if confirmYes("✅ Rename?") {
+			return renameBranch(old, name, branchMeta{base, persona, mood})
+		}
+		menu := promptui.Select{
+			Label:        "❓ What next?",
+			Items:        []string{"Generate another", "Cancel"},
+			HideSelected: true,
+		}
+		_, act, serr := menu.Run()
+		if serr != nil || act == "Cancel" {
+			fmt.Println("🚫 Aborted.")
+			return nil
+		}
+		if personaIsRandom() {
+			persona = pickPersona()
+		}
+		if branchMoodIsRandom() {
+			mood = styles.RandomMood()
+		}
+	}
+}
+
+// renameBranch runs git branch -m (which carries branch.<old>.* config
+// along) and then deals with an upstream that still has the old name.
+func renameBranch(old, name string, meta branchMeta) error {
+	upstream, _ := git.Output("rev-parse", "--abbrev-ref", "--symbolic-full-name", old+"@{upstream}")
+	if err := git.Run("branch", "-m", old, name); err != nil {
+		return err
+	}
+	recordBranchMeta(name, meta)
+	fmt.Printf("✅ Renamed %s → %s\n", old, name)
+
+	remote := git.Config("branch." + name + ".remote")
+	if upstream == "" || remote == "" || remote == "." {
+		return nil
+	}
+	fmt.Printf("⚠️  %s still tracks %s.\n", name, upstream)
+	menu := promptui.Select{
+		Label: "❓ Upstream?",
+		Items: []string{
+			"Push " + name + " to " + remote + " and track it",
+			"Push, track, and delete " + upstream,
+			"Leave it tracking " + upstream,
+		},
+		HideSelected: true,
+	}
+	i, _, err := menu.Run()
+	if err != nil || i == 2 {
+		return nil
+	}
+	if err := git.Run("push", "--set-upstream", remote, name); err != nil {
+		return err
+	}
+	if i == 1 {
+		remoteBranch := strings.TrimPrefix(upstream, remote+"/")
+		return git.Run("push", remote, "--delete", remoteBranch)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"time"
+
+	"git-randomizer/internal/git"
+	"git-randomizer/internal/notes"
+
+	"github.com/spf13/cobra"
+)
+
+var revertCmd = &cobra.Command{
+	Use:   "revert <rev>",
+	Short: "git revert with a persona subject",
+	Long: "Revert <rev> like `git revert`, restyling the subject but keeping the\n" +
+		"\"This reverts commit <sha>.\" line git and its tooling rely on.",
+	Args: cobra.ExactArgs(1),
+	RunE: runRevert,
+}
+
+func init() {
+	addPersonaFlags(revertCmd, withLength, withYes)
+	revertCmd.Flags().BoolVar(&flagTrailers, "trailers", false, "add Gitr-Persona/Gitr-Mood/Original-Message trailers")
+}
+
+/* ------------------- COMMAND ENTRY ------------------ */
+
+func runRevert(cmd *cobra.Command, args []string) error {
+	gitDir, err := git.GitDir()
+	if err != nil {
+		return err
+	}
+	sha, err := git.Output("rev-parse", "--verify", "-q", args[0]+"^{commit}")
+	if err != nil {
+		return fmt.Errorf("❌ unknown revision %q", args[0])
+	}
+	msg, err := git.Message(sha)
+	if err != nil {
+		return err
+	}
+	msg, _ = notes.Original(sha, msg)
+
+	// --no-commit reverts on top of the index; anything already staged
+	// would ride along into the revert commit
+	if _, err := git.Output("diff", "--cached", "--quiet"); err != nil {
+		return errors.New("❌ you have staged changes; commit or stash them before reverting")
+	}
+
+	// what git revert would have written (literal quotes, no escaping)
+	subject := `Revert "` + firstLine(msg) + `"`
+	footer := "This reverts commit " + sha + "."
+	orig := subject + "\n\n" + footer
+
+	apiKey, err := getAPIKey(flagPass)
+	if err != nil {
+		return err
+	}
+	rand.Seed(time.Now().UnixNano())
+	length := pickLength()
+
+	r, err := confirmLoop(orig, length, func(style, mood string) (string, error) {
+		gen, err := stylise(apiKey, style, mood, length, subject)
+		return firstLine(gen) + "\n\n" + footer, err
+	})
+	if err != nil {
+		return err
+	}
+	if r.Message == "" {
+		fmt.Println("🚫 Aborted.")
+		return nil
+	}
+	final := r.Message
+	if trailersEnabled(cmd) {
+		final = withTrailers(final, orig, r.Persona, r.Mood)
+	}
+
+	if err := git.Run("revert", "--no-commit", sha); err != nil {
+		if !inProgress("REVERT_HEAD") {
+			return err // dirty tree, merge commit without -m, …
+		}
+		// conflicts: leave our message where `git revert --continue` finds it
+		_ = os.WriteFile(filepath.Join(gitDir, "MERGE_MSG"), []byte(final+"\n"), 0o644)
+		return fmt.Errorf("❌ revert stopped; resolve, then run git revert --continue (message kept)")
+	}
+	if err := gitCommit(final); err != nil {
+		return err
+	}
+	fmt.Printf("🎉 Reverted %s.\n", shortSHA(sha))
+	recordOriginal("HEAD", orig, r)
+	return nil
+}
@@ -0,0 +1,288 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"git-randomizer/internal/gemini"
+	"git-randomizer/internal/git"
+	"git-randomizer/internal/notes"
+	"git-randomizer/internal/styles"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+/* ---------------------- FLAGS ---------------------- */
+
+var rwForce bool
+
+var rewordCmd = &cobra.Command{
+	Use:   "reword <range>",
+	Short: "Restyle every commit message in a range and rewrite history",
+	Long: "Restyle every commit message in <range> (e.g. main..HEAD, or just main)\n" +
+		"and rewrite the current branch with a generated, non-interactive rebase.",
+	Args: cobra.ExactArgs(1),
+	RunE: runReword,
+}
+
+func init() {
+	addPersonaFlags(rewordCmd, withLength, withYes)
+	rewordCmd.Flags().BoolVar(&flagTrailers, "trailers", false, "append Gitr-Persona/Gitr-Mood/Original-Message trailers")
+	rewordCmd.Flags().BoolVarP(&rwForce, "force", "f", false, "allow rewriting protected or pushed refs")
+}
+
+/* ------------------- COMMAND ENTRY ------------------ */
+
+func runReword(cmd *cobra.Command, args []string) error {
+	if _, err := git.TopLevel(); err != nil {
+		return err
+	}
+	commits, err := resolveRewriteRange(args[0], rwForce)
+	if err != nil {
+		return err
+	}
+	apiKey, err := getAPIKey(flagPass)
+	if err != nil {
+		return err
+	}
+
+	rand.Seed(time.Now().UnixNano())
+	length := pickLength()
+	mood := pickMoodOnce()
+	randomMood := moodIsRandomConfig()
+	trailers := trailersEnabled(cmd)
+
+	plans := make(map[string]rewordPlan, len(commits))
+	rows := make([][3]string, 0, len(commits))
+	for _, sha := range commits {
+		orig, err := git.Message(sha)
+		if err != nil {
+			return err
+		}
+		if rec, ok := notes.Read(sha); ok && rec.Original != "" {
+			orig = rec.Original
+		}
+		style := pickStyle()
+		if randomMood {
+			mood = styles.RandomMood()
+		}
+		fmt.Printf("🧠 %s → %s (%s)…\n", shortSHA(sha), style, mood)
+		gen, err := stylise(apiKey, style, mood, length, orig)
+		if err != nil {
+			return err
+		}
+		if trailers {
+			gen = withTrailers(gen, orig, style, mood)
+		}
+		plans[sha] = rewordPlan{
+			Message: gen,
+			Note: notes.Record{
+				Persona: style, Mood: mood, Model: gemini.Model,
+				Time: time.Now(), Original: orig,
+			}.Format(),
+		}
+		rows = append(rows, [3]string{shortSHA(sha), firstLine(orig), firstLine(gen)})
+	}
+
+	printReviewTable([3]string{"COMMIT", "ORIGINAL", "PERSONA"}, rows)
+	if !flagYes && !confirmYes("✅ Rewrite these commits?") {
+		fmt.Println("🚫 Aborted.")
+		return nil
+	}
+
+	if err := rewriteMessages(commits[0], plans); err != nil {
+		return err
+	}
+	fmt.Printf("🎉 Reworded %d commits!\n", len(plans))
+	return nil
+}
+
+/* -------------------- HELPERS --------------------- */
+
+// resolveRewriteRange returns the commits of rng oldest-first and refuses
+// anything a rebase of the current branch could not rewrite safely.
+func resolveRewriteRange(rng string, force bool) ([]string, error) {
+	if !strings.Contains(rng, "..") {
+		rng += "..HEAD"
+	}
+	commits, err := git.RevList("--reverse", rng)
+	if err != nil {
+		return nil, err
+	}
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("❌ %s selects no commits", rng)
+	}
+	for _, c := range commits {
+		if !git.IsAncestor(c, "HEAD") {
+			return nil, fmt.Errorf("❌ %s is not on the current branch", shortSHA(c))
+		}
+	}
+	merges, err := git.RevList(append([]string{"--merges"}, rebaseSpan(commits[0])...)...)
+	if err != nil {
+		return nil, err
+	}
+	if len(merges) > 0 {
+		return nil, errors.New("❌ range contains merge commits; rewriting would flatten them")
+	}
+
+	if force {
+		return commits, nil
+	}
+	if b := git.CurrentBranch(); isProtectedBranch(b) {
+		return nil, fmt.Errorf("❌ %s is a protected branch (use --force to override)", b)
+	}
+	remotes, err := git.RemoteBranchesContaining(commits[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(remotes) > 0 {
+		return nil, fmt.Errorf("❌ commits already pushed to %s (use --force to override)",
+			strings.Join(remotes, ", "))
+	}
+	return commits, nil
+}
+
+// rebaseSpan returns the rev-list arguments covering oldest..HEAD inclusive.
+func rebaseSpan(oldest string) []string {
+	if parents, _ := git.RevList("--parents", "-n1", oldest); len(parents) == 1 &&
+		len(strings.Fields(parents[0])) == 1 {
+		return []string{"HEAD"} // oldest is a root commit
+	}
+	return []string{oldest + "^..HEAD"}
+}
+
+func isProtectedBranch(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, p := range viper.GetStringSlice("protected_branches") {
+		if ok, _ := filepath.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// rewordPlan is the new message and gitr note for one commit. An empty
+// Note removes the record the rebase carried over.
+type rewordPlan struct {
+	Message string
+	Note    string
+}
+
+// rewriteMessages rebases the current branch from oldest onwards, replacing
+// the message of every commit found in plans. On any failure the rebase is
+// aborted so the branch is left exactly as it was.
+func rewriteMessages(oldest string, plans map[string]rewordPlan) error {
+	span := rebaseSpan(oldest)
+	all, err := git.RevList(append([]string{"--reverse"}, span...)...)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.MkdirTemp("", "gitr-reword-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	var todo strings.Builder
+	for _, sha := range all {
+		fmt.Fprintf(&todo, "pick %s\n", sha)
+		plan, ok := plans[sha]
+		if !ok {
+			continue
+		}
+		file := filepath.Join(tmp, sha+".msg")
+		if err := os.WriteFile(file, []byte(plan.Message+"\n"), 0o600); err != nil {
+			return err
+		}
+		fmt.Fprintf(&todo, "exec git commit --amend --only --allow-empty --no-verify --cleanup=whitespace -q -F %s\n",
+			shellQuote(file))
+		if plan.Note == "" {
+			fmt.Fprintf(&todo, "exec git notes --ref=%s remove --ignore-missing HEAD\n", notes.Ref)
+			continue
+		}
+		note := filepath.Join(tmp, sha+".note")
+		if err := os.WriteFile(note, []byte(plan.Note+"\n"), 0o600); err != nil {
+			return err
+		}
+		fmt.Fprintf(&todo, "exec git notes --ref=%s add -f -F %s HEAD\n", notes.Ref, shellQuote(note))
+	}
+	todoFile := filepath.Join(tmp, "todo")
+	if err := os.WriteFile(todoFile, []byte(todo.String()), 0o600); err != nil {
+		return err
+	}
+
+	// carry every replayed commit's gitr note along (exec'd gits inherit -c)
+	args := []string{"-c", "notes.rewriteRef=refs/notes/" + notes.Ref,
+		"rebase", "-i", "--autostash", "--keep-empty"}
+	if span[0] == "HEAD" {
+		args = append(args, "--root")
+	} else {
+		args = append(args, oldest+"^")
+	}
+	c := exec.Command("git", args...)
+	c.Stdout, c.Stderr = os.Stdout, os.Stderr
+	c.Env = append(os.Environ(),
+		"GIT_SEQUENCE_EDITOR=cp "+shellQuote(todoFile),
+		"GIT_EDITOR=true",
+	)
+	if err := c.Run(); err != nil {
+		_ = exec.Command("git", "rebase", "--abort").Run()
+		return fmt.Errorf("❌ rebase failed and was aborted, history untouched: %w", err)
+	}
+	return nil
+}
+
+func printReviewTable(head [3]string, rows [][3]string) {
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "%s\t%s\t%s\n", head[0], head[1], head[2])
+	for _, r := range rows {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", r[0], truncate(r[1], 50), truncate(r[2], 70))
+	}
+	w.Flush()
+	fmt.Println()
+}
+
+func confirmYes(label string) bool {
+	p := promptui.Prompt{Label: label, IsConfirm: true, Default: "Y"}
+	ans, err := p.Run()
+	if err != nil {
+		return false
+	}
+	return ans == "" || strings.ToLower(ans) == "y"
+}
+
+func firstLine(s string) string {
+	return strings.TrimSpace(strings.SplitN(s, "\n", 2)[0])
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
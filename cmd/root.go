@@ -12,10 +12,21 @@ import (
 
 var (
 	cfgFile string
+	workDir string
 	rootCmd = &cobra.Command{
 		Use:   "gitr",
 		Short: "Git randomizer: Rewrite git commit messages in outrageous personas",
 		Long:  "Git randomizer: jazzes up your git life: commits, branches, and celebratory one-liners – all in character!",
+		PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
+			// behave like `git -C <path>`: everything after this runs from there
+			if workDir == "" {
+				return nil
+			}
+			if err := os.Chdir(workDir); err != nil {
+				return fmt.Errorf("❌ cannot change to %s: %w", workDir, err)
+			}
+			return nil
+		},
 	}
 )
 
@@ -27,10 +38,28 @@ func init() {
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", defaultCfg,
 		"config file (default $HOME/.config/gitrandomizer/gitrandomizer.yaml)")
+	rootCmd.PersistentFlags().StringVarP(&workDir, "chdir", "C", "",
+		"run as if gitr was started in <path> (like git -C)")
 	cobra.OnInitialize(initConfig)
 
 	rootCmd.AddCommand(commitCmd)
 	rootCmd.AddCommand(branchCmd)
+	rootCmd.AddCommand(branchesCmd)
+	rootCmd.AddCommand(amendCmd)
+	rootCmd.AddCommand(rewordCmd)
+	rootCmd.AddCommand(showCmd)
+	rootCmd.AddCommand(unrandomizeCmd)
+	rootCmd.AddCommand(changelogCmd)
+	rootCmd.AddCommand(releaseCmd)
+	rootCmd.AddCommand(prCmd)
+	rootCmd.AddCommand(standupCmd)
+	rootCmd.AddCommand(logCmd)
+	rootCmd.AddCommand(explainCmd)
+	rootCmd.AddCommand(decodeCmd)
+	rootCmd.AddCommand(squashCmd)
+	rootCmd.AddCommand(stashCmd)
+	rootCmd.AddCommand(revertCmd)
+	rootCmd.AddCommand(tagCmd)
 }
 
 func initConfig() {
@@ -42,14 +71,33 @@ func initConfig() {
 	viper.SetDefault("default_mood", "playful")
 	viper.SetDefault("default_length", "medium")
 	viper.SetDefault("confirm", true)
+	viper.SetDefault("conventional_commits", "auto")
+	viper.SetDefault("subject_limit", 72)
+	viper.SetDefault("body_width", 72)
 
 	viper.SetDefault("pass_secret", "gemini_api_key")
 
 	viper.SetDefault("tagline_persona", "yoda")
 	viper.SetDefault("tagline_enabled", true)
 
+	viper.SetDefault("trailers", false)
+
 	viper.SetDefault("branch_persona", "random")
 	viper.SetDefault("branch_persona_group", "")
+	viper.SetDefault("branch_template", "{slug}")
+	viper.SetDefault("branch_switch", false)
+	viper.SetDefault("branch_types", []string{"feat", "fix", "chore", "docs", "refactor", "test"})
+	// one key at a time: viper cannot look inside a map[string]int default
+	viper.SetDefault("branch_limits.user", 20)
+	viper.SetDefault("branch_limits.type", 12)
+	viper.SetDefault("branch_limits.ticket", 20)
+	viper.SetDefault("branch_limits.slug", 40)
+
+	viper.SetDefault("protected_branches", []string{"main", "master"})
+
+	viper.SetDefault("release_prefix", "v")
+	viper.SetDefault("pr_base", "")
+	viper.SetDefault("standup_repos", []string{})
 
 	if err := viper.ReadInConfig(); err != nil {
 		// first run – drop a commented sample file
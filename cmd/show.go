@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"git-randomizer/internal/git"
+	"git-randomizer/internal/notes"
+
+	"github.com/spf13/cobra"
+)
+
+var showCmd = &cobra.Command{
+	Use:   "show [rev]",
+	Short: "Show a commit's persona message next to the original it replaced",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runShow,
+}
+
+/* ------------------- COMMAND ENTRY ------------------ */
+
+func runShow(_ *cobra.Command, args []string) error {
+	if _, err := git.GitDir(); err != nil {
+		return err
+	}
+	rev := "HEAD"
+	if len(args) == 1 {
+		rev = args[0]
+	}
+	sha, err := git.Output("rev-parse", "--verify", "-q", rev+"^{commit}")
+	if err != nil {
+		return fmt.Errorf("❌ unknown revision %q", rev)
+	}
+	msg, err := git.Message(sha)
+	if err != nil {
+		return err
+	}
+	header, _ := git.Output("log", "-1", "--format=%an · %ad", "--date=short", sha)
+	fmt.Printf("commit %s  (%s)\n", sha, header)
+
+	rec, ok := notes.Read(sha)
+	if !ok {
+		fmt.Printf("\n%s\n\nℹ️  no gitr record for this commit.\n", msg)
+		return nil
+	}
+	when := rec.Time.Local().Format("2006-01-02 15:04")
+	if rec.Decoded() {
+		fmt.Printf("🔎 decoded by %s · %s (a guess, not the recorded original)\n\n", rec.Model, when)
+		printSideBySide("PERSONA", msg, "DECODED (guess)", rec.Original, 38)
+		return nil
+	}
+	fmt.Printf("🎭 %s · %s · %s · %s\n\n", rec.Persona, rec.Mood, rec.Model, when)
+	printSideBySide("PERSONA", msg, "ORIGINAL", rec.Original, 38)
+	return nil
+}
+
+/* -------------------- HELPERS --------------------- */
+
+func printSideBySide(lh, left, rh, right string, width int) {
+	l, r := wrap(left, width), wrap(right, width)
+	row := func(a, b string) {
+		fmt.Printf("%-*s │ %s\n", width, a, b)
+	}
+	row(lh, rh)
+	row(strings.Repeat("─", len(lh)), strings.Repeat("─", len(rh)))
+	for i := 0; i < len(l) || i < len(r); i++ {
+		var a, b string
+		if i < len(l) {
+			a = l[i]
+		}
+		if i < len(r) {
+			b = r[i]
+		}
+		row(a, b)
+	}
+}
+
+// wrap breaks text into lines of at most width runes, keeping paragraphs.
+func wrap(text string, width int) []string {
+	var out []string
+	for _, para := range strings.Split(text, "\n") {
+		line := ""
+		for _, w := range strings.Fields(para) {
+			switch {
+			case line == "":
+				line = w
+			case len([]rune(line))+1+len([]rune(w)) > width:
+				out = append(out, line)
+				line = w
+			default:
+				line += " " + w
+			}
+		}
+		out = append(out, line)
+	}
+	return out
+}
@@ -0,0 +1,243 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"git-randomizer/internal/gemini"
+	"git-randomizer/internal/git"
+	"git-randomizer/internal/notes"
+
+	"github.com/spf13/cobra"
+)
+
+/* ---------------------- FLAGS ---------------------- */
+
+var (
+	sqHook   bool
+	sqOutput string
+)
+
+var squashCmd = &cobra.Command{
+	Use:   "squash [<from>..<to> | <msg-file> [<source>]]",
+	Short: "Write one persona message for a squash or merge",
+	Long: "With a range, combine its commit messages into one persona summary for a squash\n" +
+		"merge. Without one, restyle a pending .git/SQUASH_MSG or MERGE_MSG in place.\n" +
+		"Merge messages keep their \"Merge branch X into Y\" line and gain a persona line.\n\n" +
+		"As a prepare-commit-msg hook:\n" +
+		"  exec gitr squash --hook \"$1\" \"$2\"",
+	Args: cobra.MaximumNArgs(2),
+	RunE: runSquash,
+}
+
+func init() {
+	addPersonaFlags(squashCmd, withLength, withYes)
+	squashCmd.Flags().BoolVar(&flagTrailers, "trailers", false, "add Gitr-Persona/Gitr-Mood/Original-Message trailers")
+	squashCmd.Flags().BoolVar(&sqHook, "hook", false, "run as prepare-commit-msg: <msg-file> [<source>]")
+	squashCmd.Flags().StringVarP(&sqOutput, "output", "o", "", "write a range's message to this file instead of stdout")
+}
+
+// squashedCommit matches the "commit <sha>" lines git writes to SQUASH_MSG.
+var squashedCommit = regexp.MustCompile(`(?m)^commit ([0-9a-f]{40})$`)
+
+/* ------------------- COMMAND ENTRY ------------------ */
+
+func runSquash(cmd *cobra.Command, args []string) error {
+	if !sqHook {
+		return squash(cmd, args)
+	}
+	// a failing hook would abort the commit; git's own message will do
+	if err := squash(cmd, args); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  gitr: keeping git's message: %v\n", err)
+	}
+	return nil
+}
+
+func squash(cmd *cobra.Command, args []string) error {
+	gitDir, err := git.GitDir()
+	if err != nil {
+		return err
+	}
+
+	var file, kind string
+	var shas []string
+	switch {
+	case sqHook:
+		if len(args) == 0 {
+			return errors.New("❌ --hook needs the message file git passes to prepare-commit-msg")
+		}
+		if len(args) < 2 || (args[1] != "merge" && args[1] != "squash") {
+			return nil // an ordinary commit: not ours
+		}
+		// git opens the editor next; that is the review
+		file, kind, flagYes = args[0], args[1], true
+	case len(args) == 1:
+		if shas, err = git.RevList("--reverse", "--no-merges", defaultRange(args)); err != nil {
+			return err
+		}
+		if len(shas) == 0 {
+			return fmt.Errorf("❌ %s selects no commits", defaultRange(args))
+		}
+		kind = "range"
+	case len(args) > 1:
+		return errors.New("❌ a message file and source only make sense with --hook")
+	case inProgress("MERGE_HEAD"):
+		file, kind = filepath.Join(gitDir, "MERGE_MSG"), "merge"
+	default:
+		file, kind = filepath.Join(gitDir, "SQUASH_MSG"), "squash"
+		if _, err := os.Stat(file); err != nil {
+			return errors.New("❌ no range given and no squash or merge in progress")
+		}
+	}
+
+	var text, comments string
+	if file != "" {
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		text, comments = splitComments(string(raw))
+		if kind == "squash" {
+			for _, m := range squashedCommit.FindAllStringSubmatch(text, -1) {
+				shas = append([]string{m[1]}, shas...) // SQUASH_MSG lists newest first
+			}
+		}
+	}
+
+	apiKey, err := getAPIKey(flagPass)
+	if err != nil {
+		return err
+	}
+	rand.Seed(time.Now().UnixNano())
+	length := pickLength()
+
+	var orig string
+	var r rewrite
+	if kind == "merge" {
+		orig = text
+		r, err = confirmLoop(orig, length, func(style, mood string) (string, error) {
+			return mergeMessage(apiKey, style, mood, text)
+		})
+	} else {
+		if len(shas) == 0 {
+			return errors.New("❌ SQUASH_MSG lists no commits")
+		}
+		fmt.Fprintf(os.Stderr, "🧮 combining %d commits…\n", len(shas))
+		if orig, err = squashSummary(apiKey, shas); err != nil {
+			return err
+		}
+		r, err = confirmLoop(orig, length, func(style, mood string) (string, error) {
+			return stylise(apiKey, style, mood, length, orig)
+		})
+	}
+	if err != nil {
+		return err
+	}
+	if r.Message == "" {
+		fmt.Println("🚫 Aborted.")
+		return nil
+	}
+	msg := r.Message
+	if kind != "merge" {
+		msg += "\n\n" + squashList(shas)
+	}
+	if trailersEnabled(cmd) {
+		msg = withTrailers(msg, orig, r.Persona, r.Mood)
+	}
+
+	switch {
+	case file != "":
+		if err := os.WriteFile(file, []byte(msg+"\n"+comments), 0o644); err != nil {
+			return err
+		}
+		if !sqHook {
+			fmt.Printf("📝 Updated %s; finish with: git commit\n", filepath.Base(file))
+		}
+	case sqOutput != "":
+		if err := os.WriteFile(sqOutput, []byte(msg+"\n"), 0o644); err != nil {
+			return err
+		}
+		fmt.Printf("📝 Message written to %s\n", sqOutput)
+	default:
+		fmt.Println(msg)
+	}
+	return nil
+}
+
+/* -------------------- HELPERS --------------------- */
+
+// squashSummary asks for the sober combined message the persona then
+// restyles, so tokens and Conventional Commit headers get the usual care.
+func squashSummary(apiKey string, shas []string) (string, error) {
+	var material strings.Builder
+	for _, sha := range shas {
+		msg, err := git.Message(sha)
+		if err != nil {
+			return "", err
+		}
+		msg, _ = notes.Original(sha, msg)
+		fmt.Fprintf(&material, "commit %s\n%s\n\n", shortSHA(sha), stripGitrTrailers(msg))
+	}
+	return gemini.Narrate(apiKey, "", "",
+		"Combine these commits into one commit message for a squash merge: a Conventional Commit subject "+
+			"(type(scope): description) of at most 72 characters, a blank line, then '- ' bullets for the notable changes.",
+		material.String())
+}
+
+// squashList is the short record of what went into a squash.
+func squashList(shas []string) string {
+	var b strings.Builder
+	b.WriteString("Squashed commits:")
+	for _, sha := range shas {
+		msg, _ := git.Message(sha)
+		msg, _ = notes.Original(sha, msg)
+		fmt.Fprintf(&b, "\n- %s %s", shortSHA(sha), firstLine(msg))
+	}
+	return b.String()
+}
+
+// mergeMessage keeps git's merge line verbatim as the subject – tools
+// and people grep for it – and puts the persona's announcement below.
+func mergeMessage(apiKey, style, mood, text string) (string, error) {
+	subject, rest, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	line, err := gemini.Narrate(apiKey, style, mood,
+		"Announce this merge in one line of at most 72 characters, naming the branches.", subject)
+	if err != nil {
+		return "", err
+	}
+	msg := subject + "\n\n" + strings.Trim(firstLine(line), `"'`)
+	if rest = strings.TrimSpace(rest); rest != "" {
+		msg += "\n\n" + rest
+	}
+	return msg, nil
+}
+
+// inProgress reports whether pseudo-ref head (MERGE_HEAD, REVERT_HEAD…)
+// exists, i.e. an operation is waiting to be committed. show-ref, and so
+// RefExists, only sees refs/… names.
+func inProgress(head string) bool {
+	_, err := git.Output("rev-parse", "-q", "--verify", head)
+	return err == nil
+}
+
+// splitComments separates git's #-comment lines from the message proper.
+func splitComments(raw string) (text, comments string) {
+	var keep, com []string
+	for _, l := range strings.Split(raw, "\n") {
+		if strings.HasPrefix(l, "#") {
+			com = append(com, l)
+		} else {
+			keep = append(keep, l)
+		}
+	}
+	if len(com) > 0 {
+		comments = "\n" + strings.Join(com, "\n") + "\n"
+	}
+	return strings.TrimSpace(strings.Join(keep, "\n")), comments
+}
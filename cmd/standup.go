@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"git-randomizer/internal/gemini"
+	"git-randomizer/internal/git"
+	"git-randomizer/internal/notes"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+/* ---------------------- FLAGS ---------------------- */
+
+var (
+	suSince  string
+	suAuthor string
+	suPlain  bool
+)
+
+var standupCmd = &cobra.Command{
+	Use:   "standup",
+	Short: "Summarise your recent commits as a standup update, in character",
+	Long: "Collect your commits since --since across the current repo (or standup_repos\n" +
+		"from config), grouped per repo and branch, and have a persona present them.",
+	Args: cobra.NoArgs,
+	RunE: runStandup,
+}
+
+func init() {
+	addPersonaFlags(standupCmd)
+	standupCmd.Flags().StringVar(&suSince, "since", "yesterday", "how far back (anything git log --since accepts)")
+	standupCmd.Flags().StringVarP(&suAuthor, "author", "a", "me", "whose commits ('me' = git config user.email)")
+	standupCmd.Flags().BoolVar(&suPlain, "plain", false, "plain bullet list, no persona, no API call")
+}
+
+// standupItem is one commit worth mentioning.
+type standupItem struct {
+	Repo, Branch, Subject string
+}
+
+/* ------------------- COMMAND ENTRY ------------------ */
+
+func runStandup(_ *cobra.Command, _ []string) error {
+	repos := viper.GetStringSlice("standup_repos")
+	if len(repos) == 0 {
+		top, err := git.TopLevel()
+		if err != nil {
+			return err
+		}
+		repos = []string{top}
+	}
+
+	var items []standupItem
+	for _, r := range repos {
+		found, err := standupCommits(r)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  skipping %s: %v\n", r, err)
+			continue
+		}
+		items = append(items, found...)
+	}
+	if len(items) == 0 {
+		fmt.Printf("😴 No commits since %s. Bold strategy.\n", suSince)
+		return nil
+	}
+
+	report := standupList(items)
+	if suPlain {
+		fmt.Print(report)
+		return nil
+	}
+
+	apiKey, err := getAPIKey(flagPass)
+	if err != nil {
+		return err
+	}
+	rand.Seed(time.Now().UnixNano())
+	persona, mood := pickStyle(), pickMoodOnce()
+	out, err := gemini.Narrate(apiKey, persona, mood,
+		"Turn these commits into my short standup update: what I did, as at most eight '- ' bullets grouped by repository.",
+		report)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("🎤 %s, %s:\n\n%s\n", persona, mood, strings.TrimSpace(out))
+	return nil
+}
+
+/* -------------------- HELPERS --------------------- */
+
+// standupCommits lists the author's commits in repo since --since on any
+// local branch, each attributed to the first branch git reaches it from.
+func standupCommits(repo string) ([]standupItem, error) {
+	home, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chdir(expandHome(repo)); err != nil {
+		return nil, err
+	}
+	defer os.Chdir(home)
+
+	top, err := git.TopLevel()
+	if err != nil {
+		return nil, err
+	}
+	author := suAuthor
+	if author == "me" {
+		if author = git.Config("user.email"); author == "" {
+			return nil, fmt.Errorf("user.email is not set")
+		}
+	}
+	out, err := git.Output("log", "--branches", "--source", "--no-merges",
+		"--since="+suSince, "--author="+author, "--format=%H%x1f%S%x1f%B%x1e")
+	if err != nil {
+		return nil, err
+	}
+
+	var items []standupItem
+	for _, rec := range strings.Split(out, "\x1e") {
+		f := strings.SplitN(strings.TrimLeft(rec, "\n"), "\x1f", 3)
+		if len(f) < 3 {
+			continue
+		}
+		msg, _ := notes.Original(f[0], strings.TrimSpace(f[2]))
+		items = append(items, standupItem{
+			Repo:    filepath.Base(top),
+			Branch:  strings.TrimPrefix(f[1], "refs/heads/"),
+			Subject: firstLine(msg),
+		})
+	}
+	return items, nil
+}
+
+// standupList renders items as a paste-ready list, grouped per repo and
+// branch, oldest commit first.
+func standupList(items []standupItem) string {
+	var repos []string
+	branches := make(map[string][]string)
+	subjects := make(map[[2]string][]string)
+	for i := len(items) - 1; i >= 0; i-- {
+		it := items[i]
+		key := [2]string{it.Repo, it.Branch}
+		if _, ok := branches[it.Repo]; !ok {
+			repos = append(repos, it.Repo)
+		}
+		if _, ok := subjects[key]; !ok {
+			branches[it.Repo] = append(branches[it.Repo], it.Branch)
+		}
+		subjects[key] = append(subjects[key], it.Subject)
+	}
+
+	var b strings.Builder
+	for _, r := range repos {
+		fmt.Fprintf(&b, "%s:\n", r)
+		for _, br := range branches[r] {
+			fmt.Fprintf(&b, "  [%s]\n", br)
+			for _, sub := range subjects[[2]string{r, br}] {
+				fmt.Fprintf(&b, "  - %s\n", sub)
+			}
+		}
+	}
+	return b.String()
+}
+
+func expandHome(p string) string {
+	if strings.HasPrefix(p, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, p[2:])
+		}
+	}
+	return p
+}
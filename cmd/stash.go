@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"git-randomizer/internal/gemini"
+	"git-randomizer/internal/git"
+
+	"github.com/spf13/cobra"
+)
+
+/* ---------------------- FLAGS ---------------------- */
+
+var (
+	stMessage   string
+	stUntracked bool
+	stKeepIndex bool
+)
+
+var stashCmd = &cobra.Command{
+	Use:   "stash",
+	Short: "git stash with persona messages",
+}
+
+var stashPushCmd = &cobra.Command{
+	Use:   "push [-- pathspec...]",
+	Short: "Stash your changes under a persona message",
+	Long: "Like `git stash push -m`, but the message is restyled (from --message) or\n" +
+		"written from scratch by the persona after a look at your changes.",
+	RunE: runStashPush,
+}
+
+func init() {
+	addPersonaFlags(stashPushCmd, withLength, withYes)
+	stashPushCmd.Flags().StringVar(&stMessage, "message", "", "what the stash is (default: described from the changes)")
+	stashPushCmd.Flags().BoolVarP(&stUntracked, "include-untracked", "u", false, "git: stash untracked files too")
+	stashPushCmd.Flags().BoolVarP(&stKeepIndex, "keep-index", "k", false, "git: leave staged changes in place")
+	stashCmd.AddCommand(stashPushCmd)
+}
+
+/* ------------------- COMMAND ENTRY ------------------ */
+
+func runStashPush(_ *cobra.Command, args []string) error {
+	if _, err := git.TopLevel(); err != nil {
+		return err
+	}
+	orig := strings.TrimSpace(stMessage)
+	material := orig
+	if orig == "" {
+		var err error
+		if _, material, err = workInProgress(); err != nil {
+			return err
+		}
+	}
+	apiKey, err := getAPIKey(flagPass)
+	if err != nil {
+		return err
+	}
+	rand.Seed(time.Now().UnixNano())
+	length := pickLength()
+
+	r, err := confirmLoop(orig, length, func(style, mood string) (string, error) {
+		if orig != "" {
+			msg, err := stylise(apiKey, style, mood, length, orig)
+			return firstLine(msg), err
+		}
+		msg, err := gemini.Narrate(apiKey, style, mood,
+			"Write a one-line git stash message of at most 72 characters saying what this work in progress is.",
+			material)
+		return strings.Trim(firstLine(msg), `"'`), err
+	})
+	if err != nil {
+		return err
+	}
+	if r.Message == "" {
+		fmt.Println("🚫 Aborted.")
+		return nil
+	}
+
+	gitArgs := []string{"stash", "push", "-m", r.Message}
+	if stUntracked {
+		gitArgs = append(gitArgs, "--include-untracked")
+	}
+	if stKeepIndex {
+		gitArgs = append(gitArgs, "--keep-index")
+	}
+	if len(args) > 0 {
+		gitArgs = append(append(gitArgs, "--"), args...)
+	}
+	if err := git.Run(gitArgs...); err != nil {
+		return err
+	}
+	fmt.Println("🎉 Stashed. Bring it back with: git stash pop")
+	return nil
+}
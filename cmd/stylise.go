@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"git-randomizer/internal/conventional"
+	"git-randomizer/internal/gemini"
+	"git-randomizer/internal/git"
+	"git-randomizer/internal/tokens"
+
+	"github.com/spf13/viper"
+)
+
+// maxAttempts bounds how often a mangled answer is sent back to the model.
+const maxAttempts = 3
+
+// stylise runs orig through the persona while keeping intact whatever the
+// repo's conventions depend on. Every rewrite of a message goes through here.
+func stylise(apiKey, style, mood, length, orig string) (string, error) {
+	out, err := generateKeeping(apiKey, style, mood, length, orig)
+	if err != nil {
+		return "", err
+	}
+	subjectMax, width := messageLimits()
+	return enforceLimits(out, subjectMax, width), nil
+}
+
+// generateKeeping asks for the persona rewrite, retrying while the
+// Conventional Commit header is mangled or critical tokens went missing.
+func generateKeeping(apiKey, style, mood, length, orig string) (string, error) {
+	toks := tokens.Extract(orig)
+	cc, isCC := conventional.Parse(orig)
+	isCC = isCC && conventionalEnabled()
+
+	// only the description and body are the persona's to play with
+	text := orig
+	if isCC {
+		text = cc.Description
+		if cc.Body != "" {
+			text += "\n\n" + cc.Body
+		}
+	}
+
+	subjectMax, width := messageLimits()
+	hasBody := strings.Contains(strings.TrimSpace(text), "\n")
+
+	var best string
+	var missing []string
+	for i := 0; i < maxAttempts; i++ {
+		var gen string
+		var err error
+		if hasBody {
+			gen, err = gemini.GenerateWithBody(apiKey, style, mood, length, text, subjectMax, width)
+		} else {
+			gen, err = gemini.Generate(apiKey, style, mood, length, text)
+		}
+		if err != nil {
+			return "", err
+		}
+		if isCC {
+			var ok bool
+			if gen, ok = reassemble(cc, gen); !ok {
+				continue
+			}
+		}
+		if m := tokens.Missing(gen, toks); best == "" || len(m) < len(missing) {
+			best, missing = gen, m
+		}
+		if len(missing) == 0 {
+			return best, nil
+		}
+	}
+
+	if best == "" {
+		fmt.Println("⚠️  the model kept mangling the Conventional Commit header; keeping the original.")
+		return orig, nil
+	}
+	// last resort: tack the dropped references on so nothing is lost
+	if isCC {
+		cc, _ = conventional.Parse(best)
+		cc.Body = tokens.Append(cc.Body, missing)
+		return cc.String(), nil
+	}
+	return tokens.Append(best, missing), nil
+}
+
+func conventionalEnabled() bool {
+	switch strings.ToLower(viper.GetString("conventional_commits")) {
+	case "off", "false", "no":
+		return false
+	}
+	return true
+}
+
+// reassemble puts the original type/scope/footers around the generated
+// text and reports whether the result still parses with the same header.
+func reassemble(cc conventional.Message, gen string) (string, bool) {
+	desc, body, _ := strings.Cut(strings.TrimSpace(gen), "\n")
+
+	// models like to echo a prefix back; accept it only when untouched
+	if m, ok := conventional.Parse(desc); ok {
+		if !conventional.SameHeader(cc, m) {
+			return "", false
+		}
+		desc = m.Description
+	}
+	if desc = strings.TrimSpace(desc); desc == "" {
+		return "", false
+	}
+
+	out := cc
+	out.Description = desc
+	out.Body = strings.TrimSpace(body)
+	res := out.String()
+
+	check, ok := conventional.Parse(res)
+	if !ok || !conventional.SameHeader(cc, check) || len(check.Footers) != len(cc.Footers) {
+		return "", false
+	}
+	return res, true
+}
+
+// messageLimits returns the subject length cap and body wrap column. A
+// repo can override the YAML defaults with `git config gitr.subjectLimit`
+// and `git config gitr.bodyWidth`.
+func messageLimits() (subjectMax, width int) {
+	subjectMax = viper.GetInt("subject_limit")
+	width = viper.GetInt("body_width")
+	if v, err := git.Output("config", "--type=int", "--get", "gitr.subjectLimit"); err == nil {
+		subjectMax, _ = strconv.Atoi(v)
+	}
+	if v, err := git.Output("config", "--type=int", "--get", "gitr.bodyWidth"); err == nil {
+		width, _ = strconv.Atoi(v)
+	}
+	if subjectMax <= 0 {
+		subjectMax = 72
+	}
+	if width <= 0 {
+		width = 72
+	}
+	return subjectMax, width
+}
+
+// enforceLimits keeps the subject within subjectMax runes, spilling the
+// overflow into the body, and rewraps prose paragraphs at width. Footers,
+// indented lines and lists are left exactly as they are.
+func enforceLimits(msg string, subjectMax, width int) string {
+	subject, body, _ := strings.Cut(strings.TrimSpace(msg), "\n")
+	subject = strings.TrimSpace(subject)
+	body = strings.TrimSpace(body)
+
+	if len([]rune(subject)) > subjectMax {
+		words := strings.Fields(subject)
+		kept, i := "", 0
+		for ; i < len(words) && len([]rune(strings.TrimSpace(kept+" "+words[i]))) <= subjectMax; i++ {
+			kept = strings.TrimSpace(kept + " " + words[i])
+		}
+		if kept == "" {
+			// a single word (a URL, say) longer than the limit: cut it in
+			// the subject, but keep it whole in the body
+			kept, i = truncate(words[0], subjectMax), 0
+		}
+		if rest := strings.Join(words[i:], " "); rest != "" {
+			body = strings.TrimSpace(rest + "\n\n" + body)
+		}
+		subject = kept
+	}
+	if body == "" {
+		return subject
+	}
+
+	paras := strings.Split(body, "\n\n")
+	for i, p := range paras {
+		if conventional.IsFooterBlock(p) || isPreformatted(p) {
+			continue
+		}
+		paras[i] = rewrap(p, width)
+	}
+	return subject + "\n\n" + strings.Join(paras, "\n\n")
+}
+
+// rewrap reflows the prose of p to width. Footer and reference lines stay
+// on lines of their own, exactly as written.
+func rewrap(p string, width int) string {
+	var out, run []string
+	flush := func() {
+		if len(run) > 0 {
+			out = append(out, wrap(strings.Join(strings.Fields(strings.Join(run, " ")), " "), width)...)
+			run = nil
+		}
+	}
+	for _, l := range strings.Split(p, "\n") {
+		if conventional.IsFooterLine(l) || tokens.IsReference(l) {
+			flush()
+			out = append(out, strings.TrimSpace(l))
+			continue
+		}
+		run = append(run, l)
+	}
+	flush()
+	return strings.Join(out, "\n")
+}
+
+func isPreformatted(p string) bool {
+	for _, l := range strings.Split(p, "\n") {
+		t := strings.TrimLeft(l, " \t")
+		if t != l || strings.HasPrefix(t, "- ") || strings.HasPrefix(t, "* ") || strings.HasPrefix(t, "```") {
+			return true
+		}
+	}
+	return false
+}
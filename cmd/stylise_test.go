@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEnforceLimits(t *testing.T) {
+	url := "https://example.com/a/very/long/path/that/keeps/going/and/going/forever.html"
+	tests := []struct {
+		name       string
+		msg        string
+		subj, wide int
+		want       string
+	}{
+		{"short untouched", "fix: short", 72, 72, "fix: short"},
+		{
+			"overflow spills into body",
+			"fix: one two three four", 12, 72,
+			"fix: one two\n\nthree four",
+		},
+		{
+			"overflow joins existing body",
+			"fix: one two three\n\nbody text", 12, 72,
+			"fix: one two\n\nthree\n\nbody text",
+		},
+		{
+			"over-long first word kept whole in body",
+			url + " is down", 20, 72,
+			"https://example.com…\n\n" + url + "\nis down",
+		},
+		{
+			"prose rewrapped",
+			"feat: x\n\none two three four five six", 72, 10,
+			"feat: x\n\none two\nthree four\nfive six",
+		},
+		{
+			"lists and footers left alone",
+			"feat: x\n\n- one two three four five\n- six\n\nSigned-off-by: A Long Name <a@example.com>", 72, 10,
+			"feat: x\n\n- one two three four five\n- six\n\nSigned-off-by: A Long Name <a@example.com>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := enforceLimits(tt.msg, tt.subj, tt.wide); got != tt.want {
+				t.Errorf("enforceLimits(%q, %d, %d) =\n%q\nwant\n%q", tt.msg, tt.subj, tt.wide, got, tt.want)
+			}
+			if strings.Contains(tt.msg, url) && !strings.Contains(enforceLimits(tt.msg, tt.subj, tt.wide), url) {
+				t.Errorf("URL lost")
+			}
+		})
+	}
+}
+
+func TestRewrap(t *testing.T) {
+	tests := []struct {
+		name  string
+		p     string
+		width int
+		want  string
+	}{
+		{"joins and wraps", "a b\nc d e", 5, "a b c\nd e"},
+		{"footer keeps its line", "some prose internal/x.go\nFixes: #12", 72, "some prose internal/x.go\nFixes: #12"},
+		{"reference splits prose runs", "one two\nCloses: #3\nthree four", 7, "one two\nCloses: #3\nthree\nfour"},
+		{"long word not broken", "supercalifragilistic x", 5, "supercalifragilistic\nx"},
+	}
+	for _, tt := range tests {
+		if got := rewrap(tt.p, tt.width); got != tt.want {
+			t.Errorf("%s: rewrap(%q, %d) = %q, want %q", tt.name, tt.p, tt.width, got, tt.want)
+		}
+	}
+}
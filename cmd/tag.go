@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"git-randomizer/internal/git"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+/* ---------------------- FLAGS ---------------------- */
+
+var (
+	tgMessage string
+	tgForce   bool
+)
+
+var tagCmd = &cobra.Command{
+	Use:   "tag <name> [<commit>]",
+	Short: "Create an annotated tag with a persona message",
+	Args:  cobra.RangeArgs(1, 2),
+	RunE:  runTag,
+}
+
+func init() {
+	addPersonaFlags(tagCmd, withLength, withYes)
+	tagCmd.Flags().StringVar(&tgMessage, "message", "", "what the tag marks (prompted for if empty)")
+	tagCmd.Flags().BoolVarP(&tgForce, "force", "f", false, "git: replace an existing tag")
+}
+
+/* ------------------- COMMAND ENTRY ------------------ */
+
+func runTag(_ *cobra.Command, args []string) error {
+	if _, err := git.GitDir(); err != nil {
+		return err
+	}
+	name := args[0]
+	target := "HEAD"
+	if len(args) == 2 {
+		target = args[1]
+	}
+	if _, err := git.Output("check-ref-format", "refs/tags/"+name); err != nil {
+		return fmt.Errorf("❌ %q is not a valid tag name", name)
+	}
+	if git.RefExists("refs/tags/"+name) && !tgForce {
+		return fmt.Errorf("❌ tag %s already exists (use --force to replace it)", name)
+	}
+
+	orig := strings.TrimSpace(tgMessage)
+	if orig == "" {
+		p := promptui.Prompt{Label: "💬 What does " + name + " mark"}
+		ans, err := p.Run()
+		if err == promptui.ErrInterrupt || err == promptui.ErrEOF {
+			fmt.Println("\n🚫 Aborted.")
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if orig = strings.TrimSpace(ans); orig == "" {
+			fmt.Println("🚫 Aborted.")
+			return nil
+		}
+	}
+
+	apiKey, err := getAPIKey(flagPass)
+	if err != nil {
+		return err
+	}
+	rand.Seed(time.Now().UnixNano())
+	length := pickLength()
+
+	r, err := confirmFlow(orig, length, apiKey)
+	if err != nil {
+		return err
+	}
+	if r.Message == "" {
+		fmt.Println("🚫 Aborted.")
+		return nil
+	}
+
+	gitArgs := []string{"tag", "-a", name, target, "-m", r.Message}
+	if tgForce {
+		gitArgs = append(gitArgs, "--force")
+	}
+	if err := git.Run(gitArgs...); err != nil {
+		return err
+	}
+	fmt.Printf("🎉 Tagged %s. Push it with: git push origin %s\n", name, name)
+	return nil
+}
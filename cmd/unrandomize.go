@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"git-randomizer/internal/gemini"
+	"git-randomizer/internal/git"
+	"git-randomizer/internal/notes"
+
+	"github.com/spf13/cobra"
+)
+
+/* ---------------------- FLAGS ---------------------- */
+
+var (
+	unDryRun bool
+	unForce  bool
+	unYes    bool
+	unPass   string
+)
+
+var unrandomizeCmd = &cobra.Command{
+	Use:   "unrandomize <range>",
+	Short: "Restore sane commit messages in a range before it lands on main",
+	Long: "Rewrite every commit in <range> back to the original message gitr recorded\n" +
+		"(notes first, then trailers). Commits with no record get a professional rewrite.",
+	Args: cobra.ExactArgs(1),
+	RunE: runUnrandomize,
+}
+
+func init() {
+	unrandomizeCmd.Flags().BoolVarP(&unDryRun, "dry-run", "d", false, "only report what would change")
+	unrandomizeCmd.Flags().BoolVarP(&unForce, "force", "f", false, "allow rewriting protected or pushed refs")
+	unrandomizeCmd.Flags().BoolVarP(&unYes, "yes", "y", false, "skip the review prompt")
+	unrandomizeCmd.Flags().StringVarP(&unPass, "pass-secret", "p", "", "pass secret for GEMINI_API_KEY")
+}
+
+/* ------------------- COMMAND ENTRY ------------------ */
+
+func runUnrandomize(_ *cobra.Command, args []string) error {
+	if _, err := git.TopLevel(); err != nil {
+		return err
+	}
+	commits, err := resolveRewriteRange(args[0], unForce || unDryRun)
+	if err != nil {
+		return err
+	}
+
+	var apiKey string // fetched lazily: only unrecorded commits need the model
+	plans := make(map[string]rewordPlan, len(commits))
+	rows := make([][3]string, 0, len(commits))
+	for _, sha := range commits {
+		cur, err := git.Message(sha)
+		if err != nil {
+			return err
+		}
+
+		source := "note"
+		rec, ok := notes.Read(sha)
+		if ok && rec.Decoded() {
+			source = "decoded"
+		}
+		if !ok || rec.Original == "" {
+			source = "trailer"
+			rec, ok = notes.FromTrailers(cur)
+		}
+		restored := rec.Original
+		if !ok || restored == "" {
+			source = "model"
+			if apiKey == "" {
+				if apiKey, err = getAPIKey(unPass); err != nil {
+					return err
+				}
+			}
+			fmt.Printf("🧹 %s has no recorded original, asking for a sober rewrite…\n", shortSHA(sha))
+			if restored, err = gemini.Plain(apiKey, stripGitrTrailers(cur)); err != nil {
+				return err
+			}
+		}
+
+		restored = keepForeignTrailers(restored, cur)
+		if restored == cur {
+			continue
+		}
+		plans[sha] = rewordPlan{Message: restored}
+		rows = append(rows, [3]string{shortSHA(sha) + " (" + source + ")", firstLine(cur), firstLine(restored)})
+	}
+
+	if len(plans) == 0 {
+		fmt.Println("✨ Nothing to unrandomize.")
+		return nil
+	}
+	printReviewTable([3]string{"COMMIT", "CURRENT", "RESTORED"}, rows)
+	if unDryRun {
+		fmt.Printf("🔍 Dry run: %d commits would be rewritten.\n", len(plans))
+		return nil
+	}
+	if !unYes && !confirmYes("✅ Rewrite these commits?") {
+		fmt.Println("🚫 Aborted.")
+		return nil
+	}
+
+	if err := rewriteMessages(commits[0], plans); err != nil {
+		return err
+	}
+	fmt.Printf("🎉 Restored %d commits to sanity.\n", len(plans))
+	return nil
+}
+
+/* -------------------- HELPERS --------------------- */
+
+// stripGitrTrailers drops Gitr-*/Original-Message trailers from msg.
+func stripGitrTrailers(msg string) string {
+	return keepForeignTrailers(msg, "")
+}
+
+// keepForeignTrailers returns msg with its own trailers plus those of cur
+// (Signed-off-by and friends), minus the ones gitr itself added.
+func keepForeignTrailers(msg, cur string) string {
+	body, own := splitTrailers(msg)
+	_, theirs := splitTrailers(cur)
+
+	var keep [][2]string
+	seen := make(map[string]bool)
+	for _, t := range append(own, theirs...) {
+		k := strings.ToLower(t[0] + ":" + t[1])
+		if notes.IsGitrTrailer(t[0]) || seen[k] {
+			continue
+		}
+		seen[k] = true
+		keep = append(keep, t)
+	}
+	if len(keep) == 0 {
+		return body
+	}
+	// several Signed-off-by lines are normal; only drop exact repeats
+	out, err := git.AddTrailers(body, "addIfDifferent", keep)
+	if err != nil {
+		return msg
+	}
+	return out
+}
+
+// splitTrailers separates msg into its body and its trailer block.
+func splitTrailers(msg string) (string, [][2]string) {
+	msg = strings.TrimSpace(msg)
+	list, err := git.Trailers(msg)
+	if err != nil || len(list) == 0 {
+		return msg, nil
+	}
+	if i := strings.LastIndex(msg, "\n\n"); i >= 0 {
+		return strings.TrimSpace(msg[:i]), list
+	}
+	return msg, list
+}
@@ -30,6 +30,9 @@ default_group: ""           # e.g. "cartoons" – random within group
 default_mood: playful       # 'playful', 'sarcastic', or 'random'
 default_length: medium      # short | medium | long
 confirm: true               # true = ask before committing
+conventional_commits: auto  # auto = keep type(scope)!: and footers verbatim | off
+subject_limit: 72           # max subject length (per repo: git config gitr.subjectLimit 50)
+body_width: 72              # body wrap column (per repo: git config gitr.bodyWidth)
 
 # --- API key storage ----------------------------------------
 pass_secret: "gemini_api_key"   # path in 'pass' – overrides GEMINI_API_KEY
@@ -38,9 +41,24 @@ pass_secret: "gemini_api_key"   # path in 'pass' – overrides GEMINI_API_KEY
 tagline_enabled: true
 tagline_persona: yoda           # persona for the one-liner after commit
 
+# --- Commit trailers ----------------------------------------
+trailers: false                 # append Gitr-Persona / Gitr-Mood / Original-Message
+
 # --- Branch-name generator ----------------------------------
 branch_persona: random          # fixed persona OR 'random'
 branch_persona_group: ""        # e.g. "trailer_park_boys"
+branch_template: "{slug}"       # e.g. "{user}/{type}/{ticket}-{slug}"
+branch_types: [feat, fix, chore, docs, refactor, test]   # choices for {type}
+branch_limits: {user: 20, type: 12, ticket: 20, slug: 40} # max chars per placeholder
+branch_switch: false            # true = git switch -c instead of git checkout -b
+
+# --- History rewriting (reword) ------------------------------
+protected_branches: [main, master]   # globs; --force to override
+
+# --- Releases -----------------------------------------------
+release_prefix: v               # tags look like v1.2.3 ('' for 1.2.3)
+pr_base: ""                     # branch PRs target ('' = origin/HEAD, then main/master)
+standup_repos: []               # repos for gitr standup, e.g. [~/code/api, ~/code/web]
 `
 
 	return os.WriteFile(path, []byte(sample), 0o644)
@@ -0,0 +1,102 @@
+package conventional
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	headerRe = regexp.MustCompile(`^([a-zA-Z]+)(?:\(([^()\r\n]*)\))?(!)?: (.*)$`)
+	footerRe = regexp.MustCompile(`^(BREAKING CHANGE|BREAKING-CHANGE|[A-Za-z][A-Za-z0-9-]*)(: | #)`)
+)
+
+// Message is a commit message split along Conventional Commits lines:
+// type(scope)!: description, then an optional body and footers.
+type Message struct {
+	Type        string
+	Scope       string
+	Breaking    bool
+	Description string
+	Body        string
+	Footers     []string
+}
+
+// Parse splits msg into its Conventional Commit parts. ok is false when the
+// subject line has no type prefix.
+func Parse(msg string) (m Message, ok bool) {
+	msg = strings.TrimSpace(strings.ReplaceAll(msg, "\r\n", "\n"))
+	subject, rest, _ := strings.Cut(msg, "\n")
+	h := headerRe.FindStringSubmatch(strings.TrimSpace(subject))
+	if h == nil {
+		return Message{}, false
+	}
+	m = Message{
+		Type:        h[1],
+		Scope:       h[2],
+		Breaking:    h[3] == "!",
+		Description: strings.TrimSpace(h[4]),
+	}
+
+	paras := strings.Split(strings.TrimSpace(rest), "\n\n")
+	if last := paras[len(paras)-1]; IsFooterBlock(last) {
+		m.Footers = strings.Split(last, "\n")
+		paras = paras[:len(paras)-1]
+	}
+	m.Body = strings.TrimSpace(strings.Join(paras, "\n\n"))
+	return m, true
+}
+
+// Prefix returns the "type(scope)!: " part of the subject line.
+func (m Message) Prefix() string {
+	p := m.Type
+	if m.Scope != "" {
+		p += "(" + m.Scope + ")"
+	}
+	if m.Breaking {
+		p += "!"
+	}
+	return p + ": "
+}
+
+// String reassembles the message.
+func (m Message) String() string {
+	s := m.Prefix() + m.Description
+	if m.Body != "" {
+		s += "\n\n" + m.Body
+	}
+	if len(m.Footers) > 0 {
+		s += "\n\n" + strings.Join(m.Footers, "\n")
+	}
+	return s
+}
+
+// SameHeader reports whether a and b agree on type, scope and breaking marker.
+func SameHeader(a, b Message) bool {
+	return a.Type == b.Type && a.Scope == b.Scope && a.Breaking == b.Breaking
+}
+
+// IsFooterLine reports whether l starts a footer ("Token: value" or
+// "Token #value").
+func IsFooterLine(l string) bool {
+	return footerRe.MatchString(l)
+}
+
+// IsFooterBlock reports whether paragraph p consists only of footers
+// ("Token: value" / "Token #value" lines and their continuations).
+func IsFooterBlock(p string) bool {
+	p = strings.TrimSpace(p)
+	if p == "" {
+		return false
+	}
+	ls := strings.Split(p, "\n")
+	if !footerRe.MatchString(ls[0]) {
+		return false
+	}
+	for _, l := range ls[1:] {
+		// continuation lines of a footer start with whitespace
+		if !footerRe.MatchString(l) && !strings.HasPrefix(l, " ") && !strings.HasPrefix(l, "\t") {
+			return false
+		}
+	}
+	return true
+}
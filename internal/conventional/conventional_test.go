@@ -0,0 +1,104 @@
+package conventional
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want Message
+		ok   bool
+	}{
+		{"feat: add login", Message{Type: "feat", Description: "add login"}, true},
+		{"fix(auth): expire tokens", Message{Type: "fix", Scope: "auth", Description: "expire tokens"}, true},
+		{"refactor(api)!: drop v1", Message{Type: "refactor", Scope: "api", Breaking: true, Description: "drop v1"}, true},
+		{"feat!: breaking without scope", Message{Type: "feat", Breaking: true, Description: "breaking without scope"}, true},
+		{
+			"fix: x\r\n\r\nbody one\r\n\r\nbody two\r\n\r\nFixes #12\r\nReviewed-by: Z",
+			Message{Type: "fix", Description: "x", Body: "body one\n\nbody two", Footers: []string{"Fixes #12", "Reviewed-by: Z"}},
+			true,
+		},
+		{
+			"feat: x\n\nBREAKING CHANGE: config moved\n  to ~/.config",
+			Message{Type: "feat", Description: "x", Footers: []string{"BREAKING CHANGE: config moved", "  to ~/.config"}},
+			true,
+		},
+		// a body that merely mentions a colon is not a footer block
+		{"docs: y\n\nsee the README: it explains", Message{Type: "docs", Description: "y", Body: "see the README: it explains"}, true},
+
+		{"Fix the login timeout", Message{}, false},
+		{"feat:missing space", Message{}, false},
+		{"fix(a(b)): nested scope", Message{}, false},
+		{"", Message{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := Parse(tt.msg)
+		if ok != tt.ok || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Parse(%q) = %+v, %v; want %+v, %v", tt.msg, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestStringRoundTrip(t *testing.T) {
+	for _, msg := range []string{
+		"feat: add login",
+		"fix(auth)!: expire tokens",
+		"chore(deps): bump cobra\n\nAlso tidy go.sum.",
+		"feat(cli): x\n\nbody\n\nwith paragraphs\n\nRefs: #1\nSigned-off-by: A <a@example.com>",
+	} {
+		m, ok := Parse(msg)
+		if !ok {
+			t.Fatalf("Parse(%q) failed", msg)
+		}
+		if got := m.String(); got != msg {
+			t.Errorf("round trip of %q gave %q", msg, got)
+		}
+	}
+}
+
+func TestSameHeader(t *testing.T) {
+	a, _ := Parse("fix(x): one")
+	b, _ := Parse("fix(x): two")
+	c, _ := Parse("fix(x)!: two")
+	if !SameHeader(a, b) || SameHeader(a, c) {
+		t.Errorf("SameHeader: want true for %+v/%+v and false for %+v/%+v", a, b, a, c)
+	}
+}
+
+func TestFooters(t *testing.T) {
+	lines := []struct {
+		l    string
+		want bool
+	}{
+		{"Fixes #12", true},
+		{"Signed-off-by: A <a@example.com>", true},
+		{"BREAKING CHANGE: gone", true},
+		{"BREAKING-CHANGE: gone", true},
+		{"Just prose here", false},
+		{"Two words: no", false},
+		{"#12 alone", false},
+	}
+	for _, tt := range lines {
+		if got := IsFooterLine(tt.l); got != tt.want {
+			t.Errorf("IsFooterLine(%q) = %v, want %v", tt.l, got, tt.want)
+		}
+	}
+
+	blocks := []struct {
+		p    string
+		want bool
+	}{
+		{"Refs: #1\nSigned-off-by: A", true},
+		{"BREAKING CHANGE: long\n\tcontinued", true},
+		{"Refs: #1\nand some prose", false},
+		{"prose\nRefs: #1", false},
+		{"  ", false},
+	}
+	for _, tt := range blocks {
+		if got := IsFooterBlock(tt.p); got != tt.want {
+			t.Errorf("IsFooterBlock(%q) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+}
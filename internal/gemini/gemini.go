@@ -9,7 +9,8 @@ import (
 	"strings"
 )
 
-const model = "gemini-2.0-flash"
+// Model is the Gemini model every request goes to.
+const Model = "gemini-2.0-flash"
 
 type apiReq struct {
 	Contents []struct {
@@ -31,8 +32,47 @@ type apiResp struct {
 
 // Generate calls Gemini and returns a rewritten commit message.
 func Generate(apiKey, style, mood, length, commit string) (string, error) {
-	prompt := buildPrompt(commit, style, mood, length)
+	return Ask(apiKey, buildPrompt(commit, style, mood, length, ""))
+}
+
+// GenerateWithBody is Generate for messages that carry a body: the answer is
+// a subject line of at most subjectMax characters, a blank line, and a body
+// wrapped at bodyWidth columns.
+func GenerateWithBody(apiKey, style, mood, length, commit string, subjectMax, bodyWidth int) (string, error) {
+	format := fmt.Sprintf(
+		"Format it as a subject line of at most %d characters, a blank line, then a body hard-wrapped at %d columns. The length rule applies to the subject only.",
+		subjectMax, bodyWidth)
+	return Ask(apiKey, buildPrompt(commit, style, mood, length, format))
+}
+
+// Plain turns a stylised commit message back into a sober, professional one.
+func Plain(apiKey, msg string) (string, error) {
+	return Ask(apiKey, fmt.Sprintf(
+		`The git commit message below was rewritten in the voice of some character. Recover what the commit actually did and write it as a concise, professional Conventional Commit message ("type(scope): description", imperative mood, ≤ 72 characters, optional short body).
+Respond ONLY with the commit message itself – no pre-amble, no bullet points, no code fences.
+
+Commit message:
+"""%s"""`, msg))
+}
+
+// Narrate has style tell the story of material (commits, diffs, notes…)
+// with the given mood. task says what to produce and in which format;
+// facts must survive the performance.
+func Narrate(apiKey, style, mood, task, material string) (string, error) {
+	voice := fmt.Sprintf("in the voice of %s with a %s mood", style, mood)
+	if style == "" {
+		voice = "in a plain, professional tone"
+	}
+	return Ask(apiKey, fmt.Sprintf(
+		`%s Write it %s. Keep every fact, name, number, issue reference and file path accurate – only the voice changes.
+Respond ONLY with the requested text – no pre-amble, no code fences.
 
+Material:
+"""%s"""`, task, voice, material))
+}
+
+// Ask sends prompt to Gemini as-is and returns the trimmed answer.
+func Ask(apiKey, prompt string) (string, error) {
 	body := apiReq{Contents: []struct {
 		Parts []struct {
 			Text string `json:"text"`
@@ -48,7 +88,7 @@ func Generate(apiKey, style, mood, length, commit string) (string, error) {
 	payload, _ := json.Marshal(body)
 	url := fmt.Sprintf(
 		"https://generativelanguage.googleapis.com/v1beta/models/%s:generateContent?key=%s",
-		model, apiKey)
+		Model, apiKey)
 
 	httpResp, err := http.Post(url, "application/json", bytes.NewBuffer(payload))
 	if err != nil {
@@ -72,13 +112,16 @@ func Generate(apiKey, style, mood, length, commit string) (string, error) {
 	return strings.TrimSpace(r.Candidates[0].Content.Parts[0].Text), nil
 }
 
-func buildPrompt(msg, style, mood, length string) string {
+func buildPrompt(msg, style, mood, length, format string) string {
 	lengthRule := map[string]string{
 		"short":  "Keep it to MAX 8–12 words.",
 		"medium": "Aim for one punchy line (≤ 20 words).",
 		"long":   "You may use up to ~40 words (two concise lines).",
 	}
 	rule := lengthRule[length]
+	if format != "" {
+		rule += " " + format
+	}
 
 	translate := ""
 	if strings.Contains(strings.ToLower(style), "ivar aasen") {
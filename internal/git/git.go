@@ -0,0 +1,264 @@
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNotRepo is returned when the working directory is not inside a repository.
+var ErrNotRepo = errors.New("❌ not inside a git repository")
+
+// Output runs git with args and returns its trimmed stdout.
+// On failure the error carries git's own stderr text.
+func Output(args ...string) (string, error) {
+	return Pipe("", args...)
+}
+
+// Pipe is Output with input fed to git's stdin.
+func Pipe(input string, args ...string) (string, error) {
+	var stderr bytes.Buffer
+	c := exec.Command("git", args...)
+	c.Stdin = strings.NewReader(input)
+	c.Stderr = &stderr
+	out, err := c.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return strings.TrimRight(string(out), "\r\n"), nil
+}
+
+// Run runs git with args attached to the terminal.
+func Run(args ...string) error {
+	c := exec.Command("git", args...)
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return c.Run()
+}
+
+// GitDir returns the repository's git directory. Discovery is left to git
+// itself, so GIT_DIR, linked worktrees and submodules all just work.
+func GitDir() (string, error) {
+	dir, err := Output("rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return "", ErrNotRepo
+	}
+	return dir, nil
+}
+
+// TopLevel returns the root of the current work tree (honours GIT_WORK_TREE).
+func TopLevel() (string, error) {
+	if _, err := GitDir(); err != nil {
+		return "", err
+	}
+	top, err := Output("rev-parse", "--show-toplevel")
+	if err != nil || top == "" {
+		return "", errors.New("❌ this operation must be run in a work tree")
+	}
+	return top, nil
+}
+
+// Message returns the full commit message of rev.
+func Message(rev string) (string, error) {
+	return Output("log", "-1", "--format=%B", rev)
+}
+
+// RemoteBranchesContaining lists remote-tracking branches that already
+// contain rev, i.e. where rewriting it would diverge from what was pushed.
+func RemoteBranchesContaining(rev string) ([]string, error) {
+	out, err := Output("branch", "-r", "--format=%(refname:short)", "--contains", rev)
+	if err != nil {
+		return nil, err
+	}
+	return lines(out), nil
+}
+
+func lines(s string) []string {
+	var out []string
+	for _, l := range strings.Split(s, "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// RevList returns the commits selected by args (as given to git rev-list).
+func RevList(args ...string) ([]string, error) {
+	out, err := Output(append([]string{"rev-list"}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	return lines(out), nil
+}
+
+// CurrentBranch returns the checked-out branch, or "" on a detached HEAD.
+func CurrentBranch() string {
+	b, err := Output("symbolic-ref", "--short", "-q", "HEAD")
+	if err != nil {
+		return ""
+	}
+	return b
+}
+
+// IsAncestor reports whether a is reachable from b.
+func IsAncestor(a, b string) bool {
+	return exec.Command("git", "merge-base", "--is-ancestor", a, b).Run() == nil
+}
+
+// Note returns the note attached to rev under refs/notes/<ref>, or "" if none.
+func Note(ref, rev string) string {
+	n, err := Output("notes", "--ref="+ref, "show", rev)
+	if err != nil {
+		return ""
+	}
+	return n
+}
+
+// SetNote attaches (or replaces) the note for rev under refs/notes/<ref>.
+func SetNote(ref, rev, text string) error {
+	_, err := Output("notes", "--ref="+ref, "add", "-f", "-m", text, rev)
+	return err
+}
+
+// AddTrailers appends key/value trailers to msg the way
+// `git interpret-trailers` does, joining any existing trailer block.
+// ifExists is interpret-trailers' --if-exists action for keys already
+// present: "replace", "addIfDifferent", "add", …
+func AddTrailers(msg, ifExists string, trailers [][2]string) (string, error) {
+	args := []string{"interpret-trailers", "--if-exists=" + ifExists}
+	for _, t := range trailers {
+		args = append(args, "--trailer", t[0]+": "+t[1])
+	}
+	return Pipe(msg+"\n", args...)
+}
+
+// Trailers parses the trailer block of msg into key/value pairs.
+func Trailers(msg string) ([][2]string, error) {
+	out, err := Pipe(msg+"\n", "interpret-trailers", "--parse")
+	if err != nil {
+		return nil, err
+	}
+	var list [][2]string
+	for _, l := range lines(out) {
+		if k, v, ok := strings.Cut(l, ":"); ok {
+			list = append(list, [2]string{strings.TrimSpace(k), strings.TrimSpace(v)})
+		}
+	}
+	return list, nil
+}
+
+// RefExists reports whether the fully qualified ref exists.
+func RefExists(ref string) bool {
+	return exec.Command("git", "show-ref", "--verify", "--quiet", ref).Run() == nil
+}
+
+// RemoteBranchesNamed lists remote-tracking branches (remote/name) called name.
+func RemoteBranchesNamed(name string) []string {
+	out, err := Output("for-each-ref", "--format=%(refname:short)", "refs/remotes/*/"+name)
+	if err != nil {
+		return nil
+	}
+	return lines(out)
+}
+
+// Config returns the value of key, or "" when unset.
+func Config(key string) string {
+	v, _ := Output("config", "--get", key)
+	return v
+}
+
+// SetConfig writes key=value to the repository's config.
+func SetConfig(key, value string) error {
+	_, err := Output("config", key, value)
+	return err
+}
+
+// Commit is one entry returned by Log.
+type Commit struct {
+	Hash    string
+	Author  string
+	Email   string
+	Date    time.Time
+	Message string
+	Note    string // only filled in by LogNotes
+}
+
+// Subject returns the first line of the commit message.
+func (c Commit) Subject() string {
+	s, _, _ := strings.Cut(c.Message, "\n")
+	return strings.TrimSpace(s)
+}
+
+// Log runs git log with args and returns the commits it lists.
+func Log(args ...string) ([]Commit, error) {
+	return logCommits(append([]string{"log", "--format=%H%x1f%an%x1f%ae%x1f%at%x1f%B%x1e"}, args...))
+}
+
+// LogNotes is Log with each commit's note from refs/notes/<ref> read in
+// the same pass, instead of one `git notes show` per commit.
+func LogNotes(ref string, args ...string) ([]Commit, error) {
+	return logCommits(append([]string{"log", "--notes=" + ref,
+		"--format=%H%x1f%an%x1f%ae%x1f%at%x1f%B%x1f%N%x1e"}, args...))
+}
+
+func logCommits(args []string) ([]Commit, error) {
+	out, err := Output(args...)
+	if err != nil {
+		return nil, err
+	}
+	var list []Commit
+	for _, rec := range strings.Split(out, "\x1e") {
+		f := strings.SplitN(strings.TrimLeft(rec, "\n"), "\x1f", 6)
+		if len(f) < 5 {
+			continue
+		}
+		secs, _ := strconv.ParseInt(f[3], 10, 64)
+		c := Commit{
+			Hash:    f[0],
+			Author:  f[1],
+			Email:   f[2],
+			Date:    time.Unix(secs, 0),
+			Message: strings.TrimSpace(f[4]),
+		}
+		if len(f) == 6 {
+			c.Note = strings.TrimSpace(f[5])
+		}
+		list = append(list, c)
+	}
+	return list, nil
+}
+
+// ChangedFiles lists the paths touched by commit sha as {status, path}
+// pairs, status being git's one-letter A/M/D/R… code.
+func ChangedFiles(sha string) [][2]string {
+	out, err := Output("diff-tree", "--no-commit-id", "--name-status", "-r", "--root", sha)
+	if err != nil {
+		return nil
+	}
+	var list [][2]string
+	for _, l := range lines(out) {
+		f := strings.Split(l, "\t")
+		if len(f) >= 2 {
+			list = append(list, [2]string{f[0][:1], f[len(f)-1]})
+		}
+	}
+	return list
+}
+
+// TagsMerged lists the tags matching the glob pattern that are reachable
+// from rev.
+func TagsMerged(rev, pattern string) []string {
+	out, err := Output("tag", "--list", pattern, "--merged", rev)
+	if err != nil {
+		return nil
+	}
+	return lines(out)
+}
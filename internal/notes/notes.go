@@ -0,0 +1,139 @@
+package notes
+
+import (
+	"strings"
+	"time"
+
+	"git-randomizer/internal/git"
+)
+
+// Ref is the notes namespace gitr writes to (refs/notes/gitr).
+const Ref = "gitr"
+
+// Trailer keys written into the message itself when trailers are enabled.
+const (
+	TrailerPersona  = "Gitr-Persona"
+	TrailerMood     = "Gitr-Mood"
+	TrailerOriginal = "Original-Message"
+)
+
+// SourceDecoded marks a record whose Original was guessed back from the
+// persona message by `gitr decode` rather than recorded when styling.
+const SourceDecoded = "decoded"
+
+// Record is what gitr remembers about a commit it styled.
+//
+// It is stored as a small header block, a blank line, then the original
+// message verbatim, so `git log --notes=gitr` stays readable.
+type Record struct {
+	Persona  string
+	Mood     string
+	Model    string
+	Time     time.Time
+	Source   string // "" when recorded at commit time, else SourceDecoded
+	Original string
+}
+
+// Decoded reports whether r.Original is a guess by `gitr decode`.
+func (r Record) Decoded() bool {
+	return r.Source == SourceDecoded
+}
+
+// Format renders r as note text.
+func (r Record) Format() string {
+	var b strings.Builder
+	b.WriteString("persona: " + r.Persona + "\n")
+	b.WriteString("mood: " + r.Mood + "\n")
+	b.WriteString("model: " + r.Model + "\n")
+	b.WriteString("date: " + r.Time.UTC().Format(time.RFC3339) + "\n")
+	if r.Source != "" {
+		b.WriteString("source: " + r.Source + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(r.Original)
+	return b.String()
+}
+
+// Parse is the inverse of Format.
+func Parse(text string) (Record, bool) {
+	head, body, ok := strings.Cut(text, "\n\n")
+	if !ok {
+		return Record{}, false
+	}
+	var r Record
+	for _, l := range strings.Split(head, "\n") {
+		k, v, _ := strings.Cut(l, ":")
+		v = strings.TrimSpace(v)
+		switch strings.TrimSpace(k) {
+		case "persona":
+			r.Persona = v
+		case "mood":
+			r.Mood = v
+		case "model":
+			r.Model = v
+		case "date":
+			r.Time, _ = time.Parse(time.RFC3339, v)
+		case "source":
+			r.Source = v
+		}
+	}
+	r.Original = strings.TrimSpace(body)
+	return r, r.Persona != "" || r.Original != ""
+}
+
+// Read returns the record attached to rev, if any.
+func Read(rev string) (Record, bool) {
+	text := git.Note(Ref, rev)
+	if text == "" {
+		return Record{}, false
+	}
+	return Parse(text)
+}
+
+// Write attaches r to rev, replacing any earlier record.
+func Write(rev string, r Record) error {
+	return git.SetNote(Ref, rev, r.Format())
+}
+
+// FromTrailers rebuilds a (partial) record from Gitr-* trailers in msg.
+func FromTrailers(msg string) (Record, bool) {
+	list, err := git.Trailers(msg)
+	if err != nil {
+		return Record{}, false
+	}
+	var r Record
+	for _, t := range list {
+		switch {
+		case strings.EqualFold(t[0], TrailerPersona):
+			r.Persona = t[1]
+		case strings.EqualFold(t[0], TrailerMood):
+			r.Mood = t[1]
+		case strings.EqualFold(t[0], TrailerOriginal):
+			r.Original = t[1]
+		}
+	}
+	return r, r.Persona != "" || r.Original != ""
+}
+
+// IsGitrTrailer reports whether key is one of the trailers gitr writes.
+func IsGitrTrailer(key string) bool {
+	for _, k := range []string{TrailerPersona, TrailerMood, TrailerOriginal} {
+		if strings.EqualFold(key, k) {
+			return true
+		}
+	}
+	return false
+}
+
+// Original returns the pre-persona text of commit rev whose current
+// message is msg: the note if there is one, else the Original-Message
+// trailer, else msg itself.
+func Original(rev, msg string) (string, bool) {
+	if r, ok := Read(rev); ok && r.Original != "" {
+		return r.Original, true
+	}
+	if r, ok := FromTrailers(msg); ok && r.Original != "" {
+		return r.Original, true
+	}
+	return msg, false
+}
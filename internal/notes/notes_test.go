@@ -0,0 +1,32 @@
+package notes
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatParseRoundTrip(t *testing.T) {
+	when := time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)
+	tests := []Record{
+		{Persona: "yoda", Mood: "sarcastic", Model: "gemini-2.0-flash", Time: when, Original: "fix: login timeout"},
+		{Persona: "gandalf", Mood: "epic", Model: "m", Time: when, Original: "feat(cli): x\n\nbody\n\nFixes: #12"},
+		// decode writes records without a persona, marked as a guess
+		{Model: "m", Time: when, Source: SourceDecoded, Original: "chore: tidy"},
+		// colons in values must not confuse the header parser
+		{Persona: "dr. evil: the sequel", Mood: "smug", Model: "m", Time: when, Original: "a: b"},
+	}
+	for _, want := range tests {
+		got, ok := Parse(want.Format())
+		if !ok || got != want {
+			t.Errorf("Parse(Format(%+v)) = %+v, %v", want, got, ok)
+		}
+	}
+}
+
+func TestParseRejects(t *testing.T) {
+	for _, text := range []string{"", "just a message", "persona: \nmood: x\n\n"} {
+		if r, ok := Parse(text); ok {
+			t.Errorf("Parse(%q) = %+v, want !ok", text, r)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// versionRe accepts releases only: build metadata is fine, a pre-release
+// suffix (-rc1, -beta.2) is not.
+var versionRe = regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)(?:\+[0-9A-Za-z.-]+)?$`)
+
+// Bump levels, smallest first.
+const (
+	Patch = "patch"
+	Minor = "minor"
+	Major = "major"
+)
+
+// Version is a plain MAJOR.MINOR.PATCH triple.
+type Version struct {
+	Major, Minor, Patch int
+}
+
+// Parse reads tag after stripping prefix (e.g. "v"). Pre-releases such as
+// v1.3.0-rc1 are not releases and do not parse.
+func Parse(tag, prefix string) (Version, bool) {
+	m := versionRe.FindStringSubmatch(strings.TrimPrefix(tag, prefix))
+	if m == nil {
+		return Version{}, false
+	}
+	maj, _ := strconv.Atoi(m[1])
+	mnr, _ := strconv.Atoi(m[2])
+	pat, _ := strconv.Atoi(m[3])
+	return Version{maj, mnr, pat}, true
+}
+
+// Bump returns v raised by level (major, minor or patch).
+func (v Version) Bump(level string) Version {
+	switch level {
+	case Major:
+		return Version{v.Major + 1, 0, 0}
+	case Minor:
+		return Version{v.Major, v.Minor + 1, 0}
+	default:
+		return Version{v.Major, v.Minor, v.Patch + 1}
+	}
+}
+
+// Less reports whether v is an older version than o.
+func (v Version) Less(o Version) bool {
+	if v.Major != o.Major {
+		return v.Major < o.Major
+	}
+	if v.Minor != o.Minor {
+		return v.Minor < o.Minor
+	}
+	return v.Patch < o.Patch
+}
+
+// Latest returns the highest release among tags, skipping pre-releases and
+// anything else that does not parse with prefix.
+func Latest(tags []string, prefix string) (string, Version, bool) {
+	var best string
+	var bestV Version
+	for _, t := range tags {
+		v, ok := Parse(t, prefix)
+		if ok && (best == "" || bestV.Less(v)) {
+			best, bestV = t, v
+		}
+	}
+	return best, bestV, best != ""
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
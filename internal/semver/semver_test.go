@@ -0,0 +1,71 @@
+package semver
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		tag, prefix string
+		want        Version
+		ok          bool
+	}{
+		{"v1.2.3", "v", Version{1, 2, 3}, true},
+		{"1.2.3", "", Version{1, 2, 3}, true},
+		{"release-10.0.7", "release-", Version{10, 0, 7}, true},
+		{"v1.3.0+build.7", "v", Version{1, 3, 0}, true},
+
+		// pre-releases are not releases: their base was never shipped
+		{"v2.0.0-rc.1", "v", Version{}, false},
+		{"v1.3.0-rc1", "v", Version{}, false},
+		{"v1.2", "v", Version{}, false},
+		{"sunny-side", "v", Version{}, false},
+		{"1.2.3", "v", Version{1, 2, 3}, true},
+	}
+	for _, tt := range tests {
+		got, ok := Parse(tt.tag, tt.prefix)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("Parse(%q, %q) = %v, %v; want %v, %v", tt.tag, tt.prefix, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestBump(t *testing.T) {
+	v := Version{1, 4, 2}
+	tests := []struct {
+		level string
+		want  string
+	}{
+		{Patch, "1.4.3"},
+		{Minor, "1.5.0"},
+		{Major, "2.0.0"},
+		{"", "1.4.3"},
+	}
+	for _, tt := range tests {
+		if got := v.Bump(tt.level).String(); got != tt.want {
+			t.Errorf("%v.Bump(%q) = %s, want %s", v, tt.level, got, tt.want)
+		}
+	}
+	if (Version{}).Bump(Minor).String() != "0.1.0" {
+		t.Errorf("first minor release should be 0.1.0")
+	}
+}
+
+func TestLatest(t *testing.T) {
+	tests := []struct {
+		name   string
+		tags   []string
+		prefix string
+		want   string
+	}{
+		{"highest wins", []string{"v1.2.0", "v1.10.0", "v1.9.3"}, "v", "v1.10.0"},
+		{"rc skipped", []string{"v1.2.3", "v1.3.0-rc1"}, "v", "v1.2.3"},
+		{"codename skipped", []string{"rc-sunny", "v0.4.1", "nightly"}, "v", "v0.4.1"},
+		{"other prefix", []string{"v9.0.0", "release-1.0.0"}, "release-", "release-1.0.0"},
+		{"none", []string{"v1.0.0-beta"}, "v", ""},
+	}
+	for _, tt := range tests {
+		got, _, ok := Latest(tt.tags, tt.prefix)
+		if got != tt.want || ok != (tt.want != "") {
+			t.Errorf("%s: Latest(%q) = %q, %v; want %q", tt.name, tt.tags, got, ok, tt.want)
+		}
+	}
+}
@@ -131,6 +131,20 @@ func RandomFromGroup(group string) (string, bool) {
 	return "", false
 }
 
+// GroupOf returns the group persona belongs to, or "" for custom personas.
+// Personas listed in several groups resolve to the alphabetically first.
+func GroupOf(persona string) string {
+	p := strings.ToLower(strings.TrimSpace(persona))
+	for _, g := range GroupNames() {
+		for _, q := range Groups[g] {
+			if strings.ToLower(q) == p {
+				return g
+			}
+		}
+	}
+	return ""
+}
+
 func GroupNames() []string {
 	var names []string
 	for k := range Groups {
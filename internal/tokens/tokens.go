@@ -0,0 +1,120 @@
+package tokens
+
+import (
+	"regexp"
+	"strings"
+)
+
+// patterns match the bits of a commit message other tools and humans rely
+// on: issue links, ticket keys, reference lines, SHAs, URLs and file paths.
+var patterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?im)^(?:fixes|fixed|closes|closed|resolves|resolved|refs?|see|co-authored-by|reviewed-by|signed-off-by):.*$`),
+	regexp.MustCompile(`https?://\S+[^\s.,;:!?)'"]`),
+	regexp.MustCompile(`(?:[\w.-]+/[\w.-]+)?#\d+\b`),
+	regexp.MustCompile(`\b[A-Z][A-Z0-9]+-\d+\b`),
+	regexp.MustCompile(`\b[0-9a-f]*[0-9][0-9a-f]*\b`),
+	regexp.MustCompile(`(?:\.{0,2}/)?(?:[\w.-]+/)+[\w.-]*\w`),
+	regexp.MustCompile(`\b[\w-]+\.(?:go|mod|sum|js|jsx|ts|tsx|py|rb|rs|java|kt|c|h|cpp|hpp|cs|sh|md|txt|ya?ml|json|toml|ini|html|css|scss|sql|proto|lock)\b`),
+}
+
+// Extract returns the tokens in msg that a rewrite must keep, in order of
+// appearance and without duplicates or tokens nested inside longer ones.
+func Extract(msg string) []string {
+	var found []string
+	for i, re := range patterns {
+		for _, t := range re.FindAllString(msg, -1) {
+			t = strings.TrimSpace(t)
+			if i == 4 && (len(t) < 7 || len(t) > 40 || !strings.ContainsAny(t, "abcdef")) {
+				continue // too short or long for a SHA, or a plain number/date
+			}
+			if i == 5 && !looksLikePath(t) {
+				continue // "and/or", "HTTP/2", "TCP/IP"
+			}
+			if t != "" {
+				found = append(found, t)
+			}
+		}
+	}
+
+	var out []string
+	for _, t := range found {
+		if !containedIn(t, found) && !contains(out, t) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// Missing returns the tokens that do not appear in msg.
+func Missing(msg string, toks []string) []string {
+	var out []string
+	for _, t := range toks {
+		if !strings.Contains(msg, t) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// IsReference reports whether l is a reference line such as "Fixes: #12".
+func IsReference(l string) bool {
+	return patterns[0].MatchString(strings.TrimSpace(l))
+}
+
+// Append adds the missing tokens to the end of text: everything but the
+// reference lines space-separated in one paragraph, then the reference
+// lines in a paragraph of their own so rewrapping cannot merge them.
+func Append(text string, missing []string) string {
+	if len(missing) == 0 {
+		return text
+	}
+	var inline, refs []string
+	for _, t := range missing {
+		if IsReference(t) {
+			refs = append(refs, t)
+		} else {
+			inline = append(inline, t)
+		}
+	}
+	paras := []string{strings.TrimSpace(text), strings.Join(inline, " "), strings.Join(refs, "\n")}
+	var kept []string
+	for _, p := range paras {
+		if p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, "\n\n")
+}
+
+// looksLikePath keeps slash-separated words that are likely file system
+// paths: rooted or relative, nested, with an extension, or with the _ and -
+// that prose rarely puts around a slash.
+func looksLikePath(t string) bool {
+	switch {
+	case strings.HasPrefix(t, "/"), strings.HasPrefix(t, "./"), strings.HasPrefix(t, "../"):
+		return true
+	case strings.Count(t, "/") >= 2:
+		return true
+	case strings.Contains(t[strings.LastIndex(t, "/")+1:], "."):
+		return true
+	}
+	return strings.ContainsAny(t, "_-")
+}
+
+func containedIn(t string, all []string) bool {
+	for _, o := range all {
+		if o != t && strings.Contains(o, t) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(list []string, t string) bool {
+	for _, o := range list {
+		if o == t {
+			return true
+		}
+	}
+	return false
+}
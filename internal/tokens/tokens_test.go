@@ -0,0 +1,64 @@
+package tokens
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  string
+		want []string
+	}{
+		{"issue and ticket", "fix login (#42, JIRA-456)", []string{"#42", "JIRA-456"}},
+		{"repo issue", "see owner/repo#7", []string{"owner/repo#7"}},
+		{"reference line", "feat: x\n\nFixes: #12", []string{"Fixes: #12"}},
+		{"url", "docs at https://example.com/a/b.", []string{"https://example.com/a/b"}},
+		{"sha", "revert 1a2b3c4d", []string{"1a2b3c4d"}},
+		{"file and path", "touch cmd/root.go and main.go", []string{"cmd/root.go", "main.go"}},
+		{"rooted path", "clean /var/lib/gitr", []string{"/var/lib/gitr"}},
+		{"dashed path", "move config-dir/old_name", []string{"config-dir/old_name"}},
+
+		// false positives that used to cost retries
+		{"http version", "speak HTTP/2 to the proxy", nil},
+		{"and/or", "tabs and/or spaces", nil},
+		{"date", "release of 20241005", nil},
+		{"plain number", "bump limit to 1000000", nil},
+		{"short hex", "color #fff and cafe1", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Extract(tt.msg); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Extract(%q) = %q, want %q", tt.msg, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMissing(t *testing.T) {
+	toks := []string{"#12", "main.go"}
+	if got := Missing("fix #12 in the middle", toks); !reflect.DeepEqual(got, []string{"main.go"}) {
+		t.Errorf("Missing = %q, want [main.go]", got)
+	}
+}
+
+func TestAppend(t *testing.T) {
+	tests := []struct {
+		text    string
+		missing []string
+		want    string
+	}{
+		{"body", nil, "body"},
+		{"body", []string{"main.go", "#3"}, "body\n\nmain.go #3"},
+		{"body", []string{"Fixes: #12"}, "body\n\nFixes: #12"},
+		// references never share a paragraph with inline tokens
+		{"body", []string{"x.go", "Fixes: #12", "Refs: #13"}, "body\n\nx.go\n\nFixes: #12\nRefs: #13"},
+		{"", []string{"x.go"}, "x.go"},
+	}
+	for _, tt := range tests {
+		if got := Append(tt.text, tt.missing); got != tt.want {
+			t.Errorf("Append(%q, %q) = %q, want %q", tt.text, tt.missing, got, tt.want)
+		}
+	}
+}